@@ -14,14 +14,8 @@ func main() {
 		"order":   "ord",
 	}
 
-	// Create a new registry with predefined prefixes
-	registry := prefixid.NewRegistryWithPrefixes[string](prefixMap)
-
-	// Register prefixers for each entity type
-	stringPrefixer := prefixid.StringPrefixer{}
-	registry.Register("user", prefixMap["user"], stringPrefixer)
-	registry.Register("product", prefixMap["product"], stringPrefixer)
-	registry.Register("order", prefixMap["order"], stringPrefixer)
+	// Create a new registry with predefined prefixes, all using the StringPrefixer
+	registry := prefixid.NewRegistryWithPrefixer[string](prefixMap, prefixid.StringPrefixer{})
 
 	// Get a list of all registered entity types
 	entityTypes := registry.GetEntityTypes()
@@ -0,0 +1,92 @@
+package prefixid
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+)
+
+// AliasHook is called when a prefixed ID is parsed or matched with an alias
+// instead of the entity type's prefix, e.g. to log uses of a deprecated
+// prefix
+type AliasHook func(entityType, alias, prefixedID string)
+
+// WithAliasHook sets the hook called when an alias is used
+func WithAliasHook(hook AliasHook) RegistryOption {
+	return func(o *registryOptions) {
+		o.aliasHook = hook
+	}
+}
+
+// RegisterAlias adds alias prefixes to a registered entity type. IDs with an
+// alias are parsed and matched like IDs with the prefix, but PrefixID and
+// New only emit the prefix. Aliases are checked against the registry's
+// PrefixPolicy like prefixes.
+func (r *Registry[T]) RegisterAlias(entityType string, aliases ...string) error {
+	if r.scopeErr != nil {
+		return r.scopeErr
+	}
+
+	for _, alias := range aliases {
+		if err := r.policy.Validate(alias); err != nil {
+			return fmt.Errorf("entity type %s: alias: %w", entityType, err)
+		}
+	}
+
+	return r.update(func(s *registryState[T]) error {
+		prefix, ok := s.prefixes[entityType]
+		if !ok {
+			return fmt.Errorf("%w: %s", ErrUnknownEntityType, entityType)
+		}
+
+		for i, alias := range aliases {
+			if alias == prefix || slices.Contains(s.aliases[entityType], alias) || slices.Contains(aliases[:i], alias) {
+				return fmt.Errorf("entity type %s: %w: %q is already registered", entityType, ErrDuplicatePrefix, alias)
+			}
+
+			if err := r.policy.checkConflicts(entityType, alias, s.allPrefixes()); err != nil {
+				return fmt.Errorf("entity type %s: alias: %w", entityType, err)
+			}
+		}
+
+		s.aliases[entityType] = append(slices.Clone(s.aliases[entityType]), aliases...)
+		return nil
+	})
+}
+
+// Aliases returns the aliases of an entity type
+func (r *Registry[T]) Aliases(entityType string) []string {
+	return slices.Clone(r.state.Load().aliases[entityType])
+}
+
+// allPrefixes yields the prefixes and aliases of every entity type
+func (s *registryState[T]) allPrefixes() iter.Seq2[string, string] {
+	return allPrefixes(s.prefixes, s.aliases)
+}
+
+// allPrefixes yields the prefixes and aliases of every entity type in the
+// given maps
+func allPrefixes(prefixes map[string]string, aliases map[string][]string) iter.Seq2[string, string] {
+	return func(yield func(string, string) bool) {
+		for entityType, prefix := range prefixes {
+			if !yield(entityType, prefix) {
+				return
+			}
+		}
+
+		for entityType, entityAliases := range aliases {
+			for _, alias := range entityAliases {
+				if !yield(entityType, alias) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// reportAlias calls the alias hook if an alias was used
+func (r *Registry[T]) reportAlias(entityType, alias, prefixedID string) {
+	if alias != "" && r.aliasHook != nil {
+		r.aliasHook(entityType, alias, prefixedID)
+	}
+}
@@ -0,0 +1,206 @@
+package prefixid
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"sync"
+)
+
+// Catalog is a registry of entity types with different underlying ID
+// types. Entity types are registered with CatalogRegister and IDs are
+// prefixed and parsed with the generic CatalogPrefixID and Lookup functions,
+// while MatchPrefix and Resolve work out the entity type of any prefixed ID.
+type Catalog struct {
+	entries map[string]catalogEntry
+	trie    *prefixTrie
+	mutex   sync.RWMutex
+}
+
+// catalogEntry is an entity type registration with its prefixer erased to
+// the operations the catalog needs without knowing T
+type catalogEntry struct {
+	prefix   string
+	aliases  []string
+	prefixer any
+	idType   reflect.Type
+	detach   func(prefix, prefixedID string) (string, bool)
+	parse    func(s string) (any, error)
+}
+
+// NewCatalog creates a new, empty catalog
+func NewCatalog() *Catalog {
+	return &Catalog{
+		entries: make(map[string]catalogEntry),
+		trie:    &prefixTrie{},
+	}
+}
+
+// CatalogRegister adds or updates the prefix and prefixer for an entity
+// type in a catalog. The entity type's aliases are kept.
+func CatalogRegister[T any](c *Catalog, entityType, prefix string, prefixer IDPrefixer[T]) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.entries[entityType] = catalogEntry{
+		prefix:   prefix,
+		aliases:  c.entries[entityType].aliases,
+		prefixer: prefixer,
+		idType:   reflect.TypeOf((*T)(nil)).Elem(),
+		detach:   prefixer.Detach,
+		parse: func(s string) (any, error) {
+			return prefixer.Parse(s)
+		},
+	}
+	c.rebuildTrie()
+}
+
+// RegisterAlias adds alias prefixes to a registered entity type, like
+// Registry.RegisterAlias. Aliases aren't checked against a PrefixPolicy,
+// as catalog prefixes aren't.
+func (c *Catalog) RegisterAlias(entityType string, aliases ...string) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	entry, ok := c.entries[entityType]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownEntityType, entityType)
+	}
+
+	for i, alias := range aliases {
+		if alias == entry.prefix || slices.Contains(entry.aliases, alias) || slices.Contains(aliases[:i], alias) {
+			return fmt.Errorf("entity type %s: %w: %q is already registered", entityType, ErrDuplicatePrefix, alias)
+		}
+	}
+
+	entry.aliases = append(slices.Clone(entry.aliases), aliases...)
+	c.entries[entityType] = entry
+	c.rebuildTrie()
+	return nil
+}
+
+// Aliases returns the aliases of an entity type
+func (c *Catalog) Aliases(entityType string) []string {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return slices.Clone(c.entries[entityType].aliases)
+}
+
+// rebuildTrie reindexes the catalog's prefixes and aliases. The caller must
+// hold the write lock.
+func (c *Catalog) rebuildTrie() {
+	trie := &prefixTrie{}
+	for entityType, entry := range c.entries {
+		trie.insert(trieEntry{entityType: entityType, prefix: entry.prefix})
+		for _, alias := range entry.aliases {
+			trie.insert(trieEntry{entityType: entityType, prefix: alias, alias: alias})
+		}
+	}
+	c.trie = trie
+}
+
+// CatalogPrefixID creates a prefixed ID string for an entity type in a
+// catalog. T must be the ID type the entity type was registered with.
+func CatalogPrefixID[T any](c *Catalog, entityType string, id T) (string, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	entry, ok := c.entries[entityType]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrUnknownEntityType, entityType)
+	}
+
+	prefixer, ok := entry.prefixer.(IDPrefixer[T])
+	if !ok {
+		return "", fmt.Errorf("%w: %s has ID type %s, not %T", ErrTypeMismatch, entityType, entry.idType, id)
+	}
+
+	return prefixer.Attach(entry.prefix, id), nil
+}
+
+// Lookup parses a prefixed ID string for an entity type in a catalog, with
+// its prefix or one of its aliases. T must be the ID type the entity type
+// was registered with.
+func Lookup[T any](c *Catalog, entityType, prefixedID string) (T, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	var zero T
+
+	entry, ok := c.entries[entityType]
+	if !ok {
+		return zero, fmt.Errorf("%w: %s", ErrUnknownEntityType, entityType)
+	}
+
+	prefixer, ok := entry.prefixer.(IDPrefixer[T])
+	if !ok {
+		return zero, fmt.Errorf("%w: %s has ID type %s, not %s", ErrTypeMismatch, entityType, entry.idType, reflect.TypeOf((*T)(nil)).Elem())
+	}
+
+	rawStr, ok := prefixer.Detach(entry.prefix, prefixedID)
+	for i := 0; !ok && i < len(entry.aliases); i++ {
+		rawStr, ok = prefixer.Detach(entry.aliases[i], prefixedID)
+	}
+	if !ok {
+		return zero, detachError(prefixer, entityType, entry.prefix, prefixedID)
+	}
+
+	id, err := prefixer.Parse(rawStr)
+	if err != nil {
+		return zero, &ParseError{EntityType: entityType, Prefix: entry.prefix, Input: prefixedID, Kind: ErrMalformedID, Err: err}
+	}
+	return id, nil
+}
+
+// GetEntityTypes returns all registered entity types
+func (c *Catalog) GetEntityTypes() []string {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	types := make([]string, 0, len(c.entries))
+	for entityType := range c.entries {
+		types = append(types, entityType)
+	}
+	return types
+}
+
+// MatchPrefix tries to determine the entity type from a prefixed ID, by
+// longest prefix like Registry.MatchPrefix
+func (c *Catalog) MatchPrefix(prefixedID string) (string, string, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.match(prefixedID)
+}
+
+// match implements MatchPrefix. The caller must hold the read lock.
+func (c *Catalog) match(prefixedID string) (string, string, bool) {
+	var matchedType, matchedRaw string
+	ok := c.trie.match(prefixedID, func(entry *trieEntry) bool {
+		rawStr, ok := c.entries[entry.entityType].detach(entry.prefix, prefixedID)
+		if ok {
+			matchedType, matchedRaw = entry.entityType, rawStr
+		}
+		return ok
+	})
+
+	return matchedType, matchedRaw, ok
+}
+
+// Resolve determines the entity type of a prefixed ID and parses it. The
+// returned ID has the type the entity type was registered with.
+func (c *Catalog) Resolve(prefixedID string) (string, any, error) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	entityType, rawStr, ok := c.match(prefixedID)
+	if !ok {
+		return "", nil, fmt.Errorf("%w: %q", ErrUnknownPrefix, prefixedID)
+	}
+
+	entry := c.entries[entityType]
+	id, err := entry.parse(rawStr)
+	if err != nil {
+		return entityType, nil, &ParseError{EntityType: entityType, Prefix: entry.prefix, Input: prefixedID, Kind: ErrMalformedID, Err: err}
+	}
+	return entityType, id, nil
+}
@@ -0,0 +1,124 @@
+package prefixid
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// Change is a change to a registry, applied with other changes by
+// Registry.Apply
+type Change[T any] struct {
+	// EntityType to change
+	EntityType string
+	// Entry registers the entity type, replacing any existing registration,
+	// unless Remove is set
+	Entry Entry[T]
+	// Remove unregisters the entity type
+	Remove bool
+}
+
+// Lookup returns the prefix and prefixer of an entity type. The prefixer
+// is nil for prefixes registered without one.
+func (r *Registry[T]) Lookup(entityType string) (string, IDPrefixer[T], bool) {
+	s := r.state.Load()
+
+	prefix, ok := s.prefixes[entityType]
+	return prefix, s.prefixers[entityType], ok
+}
+
+// Unregister removes an entity type with its prefix, prefixer, generator
+// and aliases
+func (r *Registry[T]) Unregister(entityType string) error {
+	return r.update(func(s *registryState[T]) error {
+		if _, ok := s.prefixes[entityType]; !ok {
+			return fmt.Errorf("%w: %s", ErrUnknownEntityType, entityType)
+		}
+
+		s.remove(entityType)
+		return nil
+	})
+}
+
+// Apply applies changes in order, all or nothing. Entries are validated like
+// TryRegister, against the registry as it is after the preceding changes,
+// and removing an unregistered entity type is an error. If any change fails
+// the registry is left unchanged; otherwise all changes become visible at
+// once, so concurrent callers never see some changes without the others.
+//
+// Renaming an entity type is a Remove change followed by an Entry for the
+// new name.
+func (r *Registry[T]) Apply(changes ...Change[T]) error {
+	if r.scopeErr != nil {
+		return r.scopeErr
+	}
+
+	return r.update(func(s *registryState[T]) error {
+		return r.apply(s, changes)
+	})
+}
+
+// Replace atomically replaces every registration with entries, validated
+// like Apply
+func (r *Registry[T]) Replace(entries map[string]Entry[T]) error {
+	if r.scopeErr != nil {
+		return r.scopeErr
+	}
+
+	return r.update(func(s *registryState[T]) error {
+		changes := make([]Change[T], 0, len(s.prefixes)+len(entries))
+		for entityType := range s.prefixes {
+			changes = append(changes, Change[T]{EntityType: entityType, Remove: true})
+		}
+
+		for _, entityType := range slices.Sorted(maps.Keys(entries)) {
+			changes = append(changes, Change[T]{EntityType: entityType, Entry: entries[entityType]})
+		}
+		return r.apply(s, changes)
+	})
+}
+
+// apply applies changes to a state that hasn't been published
+func (r *Registry[T]) apply(s *registryState[T], changes []Change[T]) error {
+	for _, change := range changes {
+		entityType := change.EntityType
+
+		if change.Remove {
+			if _, ok := s.prefixes[entityType]; !ok {
+				return fmt.Errorf("%w: %s", ErrUnknownEntityType, entityType)
+			}
+			s.remove(entityType)
+			continue
+		}
+
+		if err := r.validateEntry(entityType, change.Entry, s); err != nil {
+			return err
+		}
+		s.set(entityType, change.Entry)
+	}
+	return nil
+}
+
+// validateEntry checks an entry for an entity type against the registry's
+// policy and the prefixes and aliases of other entity types
+func (r *Registry[T]) validateEntry(entityType string, entry Entry[T], s *registryState[T]) error {
+	if entry.Prefixer == nil {
+		return fmt.Errorf("%w: %s", ErrNoPrefixer, entityType)
+	}
+
+	candidates := append([]string{entry.Prefix}, entry.Aliases...)
+	for i, prefix := range candidates {
+		if err := r.policy.Validate(prefix); err != nil {
+			return fmt.Errorf("entity type %s: %w", entityType, err)
+		}
+
+		if slices.Contains(candidates[:i], prefix) {
+			return fmt.Errorf("entity type %s: %w: %q is repeated", entityType, ErrDuplicatePrefix, prefix)
+		}
+
+		if err := r.policy.checkConflicts(entityType, prefix, s.allPrefixes()); err != nil {
+			return fmt.Errorf("entity type %s: %w", entityType, err)
+		}
+	}
+	return nil
+}
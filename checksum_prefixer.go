@@ -0,0 +1,185 @@
+package prefixid
+
+import (
+	"fmt"
+	"hash/crc32"
+)
+
+// mod37Alphabet is Crockford's base32 alphabet followed by the five extra
+// check symbols
+const mod37Alphabet = crockfordBase32Upper + "*~$=U"
+
+// mod37Codec decodes check symbols case-insensitively, with the same
+// aliases as crockfordCodec
+var mod37Codec = newBaseCodec(mod37Alphabet).
+	withAliases(crockfordAliases()).
+	withAliases(map[byte]byte{'u': 'U'})
+
+// ChecksumAlgorithm selects the check ChecksumPrefixer appends to IDs
+type ChecksumAlgorithm int
+
+const (
+	// ChecksumMod37 appends a single Crockford check symbol, the ID's bytes
+	// modulo 37. It catches most single character typos and transpositions.
+	ChecksumMod37 ChecksumAlgorithm = iota
+	// ChecksumCRC32 appends the low 20 bits of the ID's CRC-32 as four
+	// Crockford base32 characters, catching more errors at the cost of
+	// longer IDs
+	ChecksumCRC32
+)
+
+// ChecksumPrefixer wraps an IDPrefixer, appending a check computed from the
+// ID to prefixed IDs, e.g. "usr_42" becomes "usr_425" with ChecksumMod37.
+//
+// Detach only succeeds for IDs with a valid check, which it strips.
+// Registry.ParsePrefixedID and the other parse functions report wrong checks
+// as errors matching ErrChecksumMismatch. Parse doesn't verify anything, it
+// only parses the underlying ID.
+//
+// The check is computed over the ID as the wrapped prefixer formats it, so
+// IDs the prefixer parses in another form, like uppercase UUIDs, are
+// verified against their canonical form. Check characters are matched
+// case-insensitively.
+type ChecksumPrefixer[T any] struct {
+	// Prefixer formats and parses the ID
+	Prefixer IDPrefixer[T]
+	// Algorithm of the check, ChecksumMod37 if unset
+	Algorithm ChecksumAlgorithm
+}
+
+var (
+	_ IDPrefixer[int] = ChecksumPrefixer[int]{}
+	_ Verifier        = ChecksumPrefixer[int]{}
+)
+
+// NewChecksumPrefixer wraps a prefixer with a checksum
+func NewChecksumPrefixer[T any](prefixer IDPrefixer[T], algorithm ChecksumAlgorithm) ChecksumPrefixer[T] {
+	return ChecksumPrefixer[T]{Prefixer: prefixer, Algorithm: algorithm}
+}
+
+// canonicalPrefix is the prefix IDs are formatted with to find their
+// canonical form
+const canonicalPrefix = "id"
+
+// Attach attaches a prefix to an ID and appends the check
+func (p ChecksumPrefixer[T]) Attach(prefix string, id T) string {
+	prefixedID := p.Prefixer.Attach(prefix, id)
+	return prefixedID + p.Algorithm.checksum(p.canonical(prefix, prefixedID))
+}
+
+// Detach verifies the check of a prefixed ID, then strips it and detaches
+// the prefix
+func (p ChecksumPrefixer[T]) Detach(prefix string, prefixedID string) (string, bool) {
+	n := p.Algorithm.size()
+	if len(prefixedID) < n {
+		return "", false
+	}
+
+	body, check := prefixedID[:len(prefixedID)-n], prefixedID[len(prefixedID)-n:]
+	rawStr, ok := p.Prefixer.Detach(prefix, body)
+	if !ok || !p.verify(rawStr, check) {
+		return "", false
+	}
+	return rawStr, true
+}
+
+// Parse parses a string into an ID
+func (p ChecksumPrefixer[T]) Parse(s string) (T, error) {
+	return p.Prefixer.Parse(s)
+}
+
+// Verify returns an error matching ErrChecksumMismatch if a prefixed ID
+// with the prefix has a missing or wrong check. IDs with another prefix
+// aren't verified.
+func (p ChecksumPrefixer[T]) Verify(prefix string, prefixedID string) error {
+	var rawStr, check string
+	ok := false
+	if n := p.Algorithm.size(); len(prefixedID) >= n {
+		check = prefixedID[len(prefixedID)-n:]
+		rawStr, ok = p.Prefixer.Detach(prefix, prefixedID[:len(prefixedID)-n])
+	}
+
+	if !ok {
+		// Without its check the ID may be too short to have the prefix
+		if _, ok := p.Prefixer.Detach(prefix, prefixedID); ok {
+			return fmt.Errorf("%w: %q has no check", ErrChecksumMismatch, prefixedID)
+		}
+		return nil
+	}
+
+	if !p.verify(rawStr, check) {
+		return fmt.Errorf("%w: %q", ErrChecksumMismatch, prefixedID)
+	}
+	return nil
+}
+
+// verify reports whether check is the check of rawStr, either as it is or
+// in the canonical form of the ID it parses to
+func (p ChecksumPrefixer[T]) verify(rawStr, check string) bool {
+	if p.Algorithm.verify(rawStr, check) {
+		return true
+	}
+
+	// rawStr may be in another form than the prefixer formats, e.g. in
+	// uppercase, so verify the check against the canonical form too
+	id, err := p.Prefixer.Parse(rawStr)
+	if err != nil {
+		return false
+	}
+	return p.Algorithm.verify(p.canonical(canonicalPrefix, p.Prefixer.Attach(canonicalPrefix, id)), check)
+}
+
+// canonical returns the unprefixed form of an ID formatted by the wrapped
+// prefixer, which the check is computed over
+func (p ChecksumPrefixer[T]) canonical(prefix, prefixedID string) string {
+	rawStr, ok := p.Prefixer.Detach(prefix, prefixedID)
+	if !ok {
+		return prefixedID
+	}
+	return rawStr
+}
+
+// size returns the number of check characters
+func (a ChecksumAlgorithm) size() int {
+	if a == ChecksumCRC32 {
+		return 4
+	}
+	return 1
+}
+
+// checksum returns the check characters for s
+func (a ChecksumAlgorithm) checksum(s string) string {
+	if a == ChecksumCRC32 {
+		sum := crc32.ChecksumIEEE([]byte(s)) & 0xFFFFF
+		var buf [4]byte
+		for i := len(buf) - 1; i >= 0; i-- {
+			buf[i] = crockfordBase32Upper[sum&0x1F]
+			sum >>= 5
+		}
+		return string(buf[:])
+	}
+
+	rem := 0
+	for i := 0; i < len(s); i++ {
+		rem = (rem<<8 | int(s[i])) % len(mod37Alphabet)
+	}
+	return mod37Alphabet[rem : rem+1]
+}
+
+// verify reports whether check is the checksum of s, ignoring case and
+// Crockford aliases
+func (a ChecksumAlgorithm) verify(s, check string) bool {
+	codec := crockfordCodec
+	if a != ChecksumCRC32 {
+		codec = mod37Codec
+	}
+
+	expected := a.checksum(s)
+	for i := 0; i < len(check); i++ {
+		digit := codec.decode[check[i]]
+		if digit == invalidDigit || codec.alphabet[digit] != expected[i] {
+			return false
+		}
+	}
+	return true
+}
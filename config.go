@@ -0,0 +1,266 @@
+package prefixid
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/oklog/ulid/v2"
+	"github.com/segmentio/ksuid"
+	"gopkg.in/yaml.v3"
+)
+
+// IDKind names the ID type of an entity type in a Config
+type IDKind string
+
+const (
+	// KindUUID is uuid.UUID, prefixed by UUIDPrefixer
+	KindUUID IDKind = "uuid"
+	// KindULID is ulid.ULID, prefixed by ULIDPrefixer
+	KindULID IDKind = "ulid"
+	// KindKSUID is ksuid.KSUID, prefixed by KSUIDPrefixer
+	KindKSUID IDKind = "ksuid"
+	// KindInt is int, prefixed by IntPrefixer
+	KindInt IDKind = "int"
+	// KindString is string, prefixed by StringPrefixer
+	KindString IDKind = "string"
+)
+
+// prefixer returns the built-in prefixer for IDs of the kind, or nil for
+// unknown kinds
+func (k IDKind) prefixer() any {
+	switch k {
+	case KindUUID:
+		return UUIDPrefixer{}
+	case KindULID:
+		return ULIDPrefixer{}
+	case KindKSUID:
+		return KSUIDPrefixer{}
+	case KindInt:
+		return IntPrefixer{}
+	case KindString:
+		return StringPrefixer{}
+	default:
+		return nil
+	}
+}
+
+// Config defines the entity types of a registry, as read from a JSON or
+// YAML file like
+//
+//	entities:
+//	  - entity: user
+//	    prefix: usr
+//	    kind: uuid
+//	    aliases: [user]
+//	    description: Registered users
+type Config struct {
+	Entities []EntityConfig `json:"entities" yaml:"entities"`
+}
+
+// EntityConfig defines an entity type in a Config
+type EntityConfig struct {
+	// Entity is the entity type
+	Entity string `json:"entity" yaml:"entity"`
+	// Prefix of the entity type's IDs
+	Prefix string `json:"prefix" yaml:"prefix"`
+	// Kind of the entity type's IDs
+	Kind IDKind `json:"kind" yaml:"kind"`
+	// Aliases accepted in addition to the prefix, optional
+	Aliases []string `json:"aliases,omitempty" yaml:"aliases,omitempty"`
+	// Description of the entity type, for documentation only
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+// ReadJSONConfig reads a Config from a single JSON document. Empty input,
+// trailing input and unknown fields are rejected.
+func ReadJSONConfig(r io.Reader) (Config, error) {
+	var config Config
+
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&config); err != nil {
+		return Config{}, decodeError(err)
+	}
+
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		return Config{}, fmt.Errorf("%w: unexpected input after the config", ErrInvalidConfig)
+	}
+	return config, nil
+}
+
+// ReadYAMLConfig reads a Config from a single YAML document. Empty input,
+// further documents and unknown fields are rejected.
+func ReadYAMLConfig(r io.Reader) (Config, error) {
+	var config Config
+
+	decoder := yaml.NewDecoder(r)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&config); err != nil {
+		return Config{}, decodeError(err)
+	}
+
+	var extra yaml.Node
+	if err := decoder.Decode(&extra); !errors.Is(err, io.EOF) {
+		return Config{}, fmt.Errorf("%w: unexpected input after the config", ErrInvalidConfig)
+	}
+	return config, nil
+}
+
+// decodeError wraps an error decoding a config in ErrInvalidConfig
+func decodeError(err error) error {
+	if errors.Is(err, io.EOF) {
+		return fmt.Errorf("%w: empty input", ErrInvalidConfig)
+	}
+	return fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+}
+
+// LoadConfig reads a Config from a file, as JSON if its name ends in .json
+// and as YAML if it ends in .yaml or .yml
+func LoadConfig(fsys fs.FS, name string) (Config, error) {
+	var read func(io.Reader) (Config, error)
+	switch path.Ext(name) {
+	case ".json":
+		read = ReadJSONConfig
+	case ".yaml", ".yml":
+		read = ReadYAMLConfig
+	default:
+		return Config{}, fmt.Errorf("%w: %s is not a .json, .yaml or .yml file", ErrInvalidConfig, name)
+	}
+
+	f, err := fsys.Open(name)
+	if err != nil {
+		return Config{}, err
+	}
+	defer f.Close()
+
+	config, err := read(f)
+	if err != nil {
+		return Config{}, fmt.Errorf("%s: %w", name, err)
+	}
+	return config, nil
+}
+
+// Validate checks every entity of the config, returning all the problems
+// found. Entities need a unique name and a known kind, and their prefixes
+// and aliases must satisfy the policy without duplicating or overlapping
+// those of other entities.
+func (c Config) Validate(policy PrefixPolicy) error {
+	var errs []error
+	prefixes := make(map[string]string)
+	aliases := make(map[string][]string)
+
+	for i, entity := range c.Entities {
+		if entity.Entity == "" {
+			errs = append(errs, fmt.Errorf("%w: entity %d has no name", ErrInvalidConfig, i+1))
+			continue
+		}
+
+		if _, ok := prefixes[entity.Entity]; ok {
+			errs = append(errs, fmt.Errorf("%w: entity %s is defined more than once", ErrInvalidConfig, entity.Entity))
+			continue
+		}
+
+		if entity.Kind.prefixer() == nil {
+			errs = append(errs, fmt.Errorf("%w: entity %s has unknown kind %q", ErrInvalidConfig, entity.Entity, entity.Kind))
+		}
+
+		candidates := append([]string{entity.Prefix}, entity.Aliases...)
+		for j, prefix := range candidates {
+			if err := policy.Validate(prefix); err != nil {
+				errs = append(errs, fmt.Errorf("entity %s: %w", entity.Entity, err))
+			} else if slices.Contains(candidates[:j], prefix) {
+				errs = append(errs, fmt.Errorf("entity %s: %w: %q is repeated", entity.Entity, ErrDuplicatePrefix, prefix))
+			} else if err := policy.checkConflicts(entity.Entity, prefix, allPrefixes(prefixes, aliases)); err != nil {
+				errs = append(errs, fmt.Errorf("entity %s: %w", entity.Entity, err))
+			}
+		}
+
+		prefixes[entity.Entity] = entity.Prefix
+		aliases[entity.Entity] = entity.Aliases
+	}
+
+	return errors.Join(errs...)
+}
+
+// ConfigEntries returns the registry entries of the config's entities with
+// IDs of type T, e.g. to pass to Registry.Replace when the config is
+// reloaded. Entities of other kinds are left out, so a config mixing kinds
+// can be split across registries. The config isn't validated.
+func ConfigEntries[T any](c Config) map[string]Entry[T] {
+	entries := make(map[string]Entry[T], len(c.Entities))
+	for _, entity := range c.Entities {
+		prefixer, ok := entity.Kind.prefixer().(IDPrefixer[T])
+		if !ok {
+			continue
+		}
+
+		entries[entity.Entity] = Entry[T]{
+			Prefix:   entity.Prefix,
+			Prefixer: prefixer,
+			Aliases:  entity.Aliases,
+		}
+	}
+	return entries
+}
+
+// NewRegistryFromConfig creates a registry with the entities of a config,
+// validated against the registry's PrefixPolicy. Every entity must have IDs
+// of type T, or it fails with ErrTypeMismatch; use ConfigEntries to pick
+// the entities of one kind, or NewCatalogFromConfig for configs mixing
+// kinds.
+func NewRegistryFromConfig[T any](c Config, opts ...RegistryOption) (*Registry[T], error) {
+	r := NewRegistry[T](opts...)
+	if err := c.Validate(r.policy); err != nil {
+		return nil, err
+	}
+
+	for _, entity := range c.Entities {
+		if _, ok := entity.Kind.prefixer().(IDPrefixer[T]); !ok {
+			var zero T
+			return nil, fmt.Errorf("%w: entity %s has kind %q, not %T", ErrTypeMismatch, entity.Entity, entity.Kind, zero)
+		}
+	}
+
+	if err := r.Replace(ConfigEntries[T](c)); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// NewCatalogFromConfig creates a catalog with the entities of a config,
+// which may be of different kinds. The config is validated against
+// DefaultPrefixPolicy.
+func NewCatalogFromConfig(c Config) (*Catalog, error) {
+	if err := c.Validate(DefaultPrefixPolicy); err != nil {
+		return nil, err
+	}
+
+	catalog := NewCatalog()
+	for _, entity := range c.Entities {
+		switch prefixer := entity.Kind.prefixer().(type) {
+		case IDPrefixer[uuid.UUID]:
+			CatalogRegister(catalog, entity.Entity, entity.Prefix, prefixer)
+		case IDPrefixer[ulid.ULID]:
+			CatalogRegister(catalog, entity.Entity, entity.Prefix, prefixer)
+		case IDPrefixer[ksuid.KSUID]:
+			CatalogRegister(catalog, entity.Entity, entity.Prefix, prefixer)
+		case IDPrefixer[int]:
+			CatalogRegister(catalog, entity.Entity, entity.Prefix, prefixer)
+		case IDPrefixer[string]:
+			CatalogRegister(catalog, entity.Entity, entity.Prefix, prefixer)
+		}
+
+		if len(entity.Aliases) > 0 {
+			if err := catalog.RegisterAlias(entity.Entity, entity.Aliases...); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return catalog, nil
+}
@@ -0,0 +1,132 @@
+package prefixid
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+const (
+	base58Alphabet       = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+	base62Alphabet       = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	crockfordBase32Upper = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+	crockfordBase32Lower = "0123456789abcdefghjkmnpqrstvwxyz"
+	invalidDigit         = 0xFF
+)
+
+var errOverflow = errors.New("value overflows the decoded size")
+
+// baseCodec encodes byte strings as fixed-width, big-endian numbers in an
+// arbitrary base. With an alphabet in ASCII order, encoded strings sort like
+// the bytes they encode.
+type baseCodec struct {
+	alphabet string
+	decode   [256]byte
+}
+
+func newBaseCodec(alphabet string) *baseCodec {
+	c := &baseCodec{alphabet: alphabet}
+	for i := range c.decode {
+		c.decode[i] = invalidDigit
+	}
+	for i := 0; i < len(alphabet); i++ {
+		c.decode[alphabet[i]] = byte(i)
+	}
+	return c
+}
+
+// withAliases returns the codec with extra characters decoding to the
+// digits of the given characters
+func (c *baseCodec) withAliases(aliases map[byte]byte) *baseCodec {
+	for alias, digit := range aliases {
+		c.decode[alias] = c.decode[digit]
+	}
+	return c
+}
+
+var (
+	base58Codec = newBaseCodec(base58Alphabet)
+	base62Codec = newBaseCodec(base62Alphabet)
+	// crockfordCodec decodes case-insensitively and maps I, L and O to 1 and
+	// 0, as the Crockford specification suggests
+	crockfordCodec = newBaseCodec(crockfordBase32Upper).withAliases(crockfordAliases())
+)
+
+func crockfordAliases() map[byte]byte {
+	aliases := map[byte]byte{'I': '1', 'i': '1', 'L': '1', 'l': '1', 'O': '0', 'o': '0'}
+	for i := 10; i < len(crockfordBase32Upper); i++ {
+		aliases[crockfordBase32Lower[i]] = crockfordBase32Upper[i]
+	}
+	return aliases
+}
+
+// base returns the number of digits in the codec's alphabet
+func (c *baseCodec) base() int {
+	return len(c.alphabet)
+}
+
+// encodedLen returns the number of digits needed to encode n bytes
+func (c *baseCodec) encodedLen(n int) int {
+	return int(math.Ceil(float64(n*8) / math.Log2(float64(c.base()))))
+}
+
+// appendEncode appends the fixed-width encoding of src to dst
+func (c *baseCodec) appendEncode(dst []byte, src []byte) []byte {
+	var buf [32]byte
+	num := append(buf[:0], src...)
+	base := c.base()
+
+	width := c.encodedLen(len(src))
+	start := len(dst)
+	for i := 0; i < width; i++ {
+		dst = append(dst, 0)
+	}
+
+	for i := width - 1; i >= 0; i-- {
+		rem := 0
+		for j := range num {
+			acc := rem<<8 | int(num[j])
+			num[j] = byte(acc / base)
+			rem = acc % base
+		}
+		dst[start+i] = c.alphabet[rem]
+	}
+	return dst
+}
+
+// encode returns the fixed-width encoding of src
+func (c *baseCodec) encode(src []byte) string {
+	var buf [64]byte
+	return string(c.appendEncode(buf[:0], src))
+}
+
+// decodeInto decodes a fixed-width encoding into dst, which must have the
+// decoded size
+func (c *baseCodec) decodeInto(dst []byte, s string) error {
+	if len(s) != c.encodedLen(len(dst)) {
+		return fmt.Errorf("invalid length %d, expected %d", len(s), c.encodedLen(len(dst)))
+	}
+
+	for i := range dst {
+		dst[i] = 0
+	}
+
+	base := c.base()
+	for i := 0; i < len(s); i++ {
+		digit := c.decode[s[i]]
+		if digit == invalidDigit {
+			return fmt.Errorf("invalid character %q at position %d", s[i], i)
+		}
+
+		carry := int(digit)
+		for j := len(dst) - 1; j >= 0; j-- {
+			acc := int(dst[j])*base + carry
+			dst[j] = byte(acc)
+			carry = acc >> 8
+		}
+		if carry != 0 {
+			return errOverflow
+		}
+	}
+	return nil
+}
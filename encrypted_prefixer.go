@@ -0,0 +1,165 @@
+package prefixid
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// encryptedBodyLen is the length of an encrypted ID: the key ID and a
+// 16-byte block in base32
+const encryptedBodyLen = 1 + typeIDSuffixLen
+
+// BlockID is the set of 16-byte ID types, such as uuid.UUID and ulid.ULID
+type BlockID interface {
+	~[16]byte
+}
+
+// EncryptedPrefixer implements IDPrefixer for 16-byte IDs, encrypting them
+// with AES so prefixed IDs reveal nothing about the underlying ID, like the
+// timestamp of a ULID or UUIDv7. The ID is the key ID followed by the
+// encrypted ID as 26 lowercase Crockford base32 characters.
+//
+// Keys come from a Keyring: the current key encrypts and any key in the
+// ring decrypts. Every 27 character string decrypts to some ID, so an ID
+// that parses isn't necessarily one that was issued; wrap the prefixer in a
+// SignedPrefixer if that matters.
+type EncryptedPrefixer[T BlockID] struct {
+	// Format controls how the prefix and ID are joined
+	Format Format
+	// Keyring holds the encryption keys
+	Keyring *Keyring
+}
+
+var _ IDPrefixer[uuid.UUID] = EncryptedPrefixer[uuid.UUID]{}
+
+// NewEncryptedPrefixer creates an EncryptedPrefixer with keys from keyring
+// and the given format options
+func NewEncryptedPrefixer[T BlockID](keyring *Keyring, opts ...FormatOption) EncryptedPrefixer[T] {
+	return EncryptedPrefixer[T]{Format: NewFormat(opts...), Keyring: keyring}
+}
+
+// Attach attaches a prefix to an encrypted ID
+func (p EncryptedPrefixer[T]) Attach(prefix string, id T) string {
+	block := [16]byte(id)
+	return p.Format.Attach(prefix, encryptBlock(p.Keyring, block))
+}
+
+// Detach detaches a prefix from a prefixed ID string
+func (p EncryptedPrefixer[T]) Detach(prefix string, prefixedID string) (string, bool) {
+	return p.Format.Detach(prefix, prefixedID)
+}
+
+// Parse decrypts a string into an ID
+func (p EncryptedPrefixer[T]) Parse(s string) (T, error) {
+	block, err := decryptBlock(p.Keyring, s)
+	if err != nil {
+		return T{}, err
+	}
+	return T(block), nil
+}
+
+// EncryptedIntegerPrefixer implements IDPrefixer for integer IDs,
+// encrypting them with AES so sequential IDs reveal neither their order nor
+// how many there are. IDs look like those of EncryptedPrefixer.
+//
+// The integer fills half of the encrypted block and the other half must
+// decrypt to zeros, so Parse rejects all but a negligible fraction of
+// forged or mistyped IDs.
+type EncryptedIntegerPrefixer[T Integer] struct {
+	// Format controls how the prefix and ID are joined
+	Format Format
+	// Keyring holds the encryption keys
+	Keyring *Keyring
+}
+
+var _ IDPrefixer[int64] = EncryptedIntegerPrefixer[int64]{}
+
+// NewEncryptedIntegerPrefixer creates an EncryptedIntegerPrefixer with keys
+// from keyring and the given format options
+func NewEncryptedIntegerPrefixer[T Integer](keyring *Keyring, opts ...FormatOption) EncryptedIntegerPrefixer[T] {
+	return EncryptedIntegerPrefixer[T]{Format: NewFormat(opts...), Keyring: keyring}
+}
+
+// Attach attaches a prefix to an encrypted integer ID
+func (p EncryptedIntegerPrefixer[T]) Attach(prefix string, id T) string {
+	var block [16]byte
+	binary.BigEndian.PutUint64(block[:8], uint64(id))
+	return p.Format.Attach(prefix, encryptBlock(p.Keyring, block))
+}
+
+// Detach detaches a prefix from a prefixed ID string
+func (p EncryptedIntegerPrefixer[T]) Detach(prefix string, prefixedID string) (string, bool) {
+	return p.Format.Detach(prefix, prefixedID)
+}
+
+// Parse decrypts a string into an integer ID
+func (p EncryptedIntegerPrefixer[T]) Parse(s string) (T, error) {
+	block, err := decryptBlock(p.Keyring, s)
+	if err != nil {
+		return 0, err
+	}
+
+	if binary.BigEndian.Uint64(block[8:]) != 0 {
+		return 0, fmt.Errorf("%w: %q", ErrDecryptionFailed, s)
+	}
+
+	// Attach sign-extends negative IDs, so only values that fit T survive
+	// the round trip through T
+	n := binary.BigEndian.Uint64(block[:8])
+	id := T(n)
+	if uint64(id) != n {
+		return 0, fmt.Errorf("%w: %q", ErrDecryptionFailed, s)
+	}
+	return id, nil
+}
+
+// encryptBlock encrypts a block with the keyring's current key
+func encryptBlock(keyring *Keyring, block [16]byte) string {
+	keyID, c := keyring.currentCipher()
+	c.Encrypt(block[:], block[:])
+
+	var buf [encryptedBodyLen]byte
+	return string(typeIDCodec.appendEncode(append(buf[:0], keyID), block[:]))
+}
+
+// decryptBlock decrypts a block encrypted by encryptBlock
+func decryptBlock(keyring *Keyring, s string) ([16]byte, error) {
+	var block [16]byte
+	if len(s) != encryptedBodyLen {
+		return block, fmt.Errorf("%w: %q is not %d characters", ErrDecryptionFailed, s, encryptedBodyLen)
+	}
+
+	c, ok := keyring.keyCipher(s[0])
+	if !ok {
+		return block, fmt.Errorf("%w: unknown key ID %q", ErrDecryptionFailed, s[0])
+	}
+
+	if err := typeIDCodec.decodeInto(block[:], s[1:]); err != nil {
+		return block, fmt.Errorf("%w: %q: %v", ErrDecryptionFailed, s, err)
+	}
+
+	c.Decrypt(block[:], block[:])
+	return block, nil
+}
+
+// blockCipher returns AES-256 with a key derived from a keyring key, so
+// keys of any length can be used and aren't used for HMAC as well. Keyring
+// calls it once per key, as the derivation and key schedule are too slow
+// to repeat for every ID.
+func blockCipher(key []byte) cipher.Block {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte("prefixid encryption key"))
+
+	c, err := aes.NewCipher(mac.Sum(nil))
+	if err != nil {
+		// Unreachable, SHA-256 sums are valid AES-256 keys
+		panic(err)
+	}
+	return c
+}
@@ -0,0 +1,84 @@
+package prefixid
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrUnknownEntityType is returned when no prefix is registered for an entity type
+	ErrUnknownEntityType = errors.New("no prefix registered for entity type")
+	// ErrNoPrefixer is returned when no prefixer is registered for an entity type
+	ErrNoPrefixer = errors.New("no prefixer registered for entity type")
+	// ErrPrefixMismatch is returned when a prefixed ID doesn't carry the expected prefix
+	ErrPrefixMismatch = errors.New("invalid prefix format for entity type")
+	// ErrMalformedID is returned when the ID after the prefix cannot be parsed
+	ErrMalformedID = errors.New("malformed ID for entity type")
+
+	// ErrUnknownPrefix is returned when no registered prefix matches a prefixed ID
+	ErrUnknownPrefix = errors.New("no entity type registered for prefix")
+	// ErrTypeMismatch is returned when an entity type is used with a different ID type than it was registered with
+	ErrTypeMismatch = errors.New("ID type mismatch")
+	// ErrNoGenerator is returned by Registry.New when no generator is registered for an entity type
+	ErrNoGenerator = errors.New("no generator registered for entity type")
+
+	// ErrInvalidTypeID is returned for strings that don't follow the TypeID specification
+	ErrInvalidTypeID = errors.New("invalid TypeID")
+	// ErrInvalidSqidsConfig is returned by NewSqidsPrefixer for unusable configs
+	ErrInvalidSqidsConfig = errors.New("invalid Sqids config")
+	// ErrChecksumMismatch is returned by ChecksumPrefixer when an ID's check doesn't match
+	ErrChecksumMismatch = errors.New("checksum mismatch")
+	// ErrInvalidSignature is returned for signed IDs that are forged, tampered with or signed with an unknown key
+	ErrInvalidSignature = errors.New("invalid signature")
+	// ErrDecryptionFailed is returned for encrypted IDs that cannot be decrypted
+	ErrDecryptionFailed = errors.New("cannot decrypt ID")
+	// ErrInvalidKey is returned when a Keyring is given an unusable key or key ID
+	ErrInvalidKey = errors.New("invalid key")
+	// ErrInvalidConfig is returned for registry configs that cannot be read or are invalid
+	ErrInvalidConfig = errors.New("invalid config")
+
+	// ErrInvalidPrefix is returned when a prefix doesn't satisfy the registry's PrefixPolicy
+	ErrInvalidPrefix = errors.New("invalid prefix")
+	// ErrDuplicatePrefix is returned when a prefix is already used by another entity type
+	ErrDuplicatePrefix = errors.New("duplicate prefix")
+	// ErrPrefixOverlap is returned when a prefix is a strict prefix of another, or the reverse
+	ErrPrefixOverlap = errors.New("overlapping prefix")
+	// ErrEntityTypeExists is returned when an entity type is already registered
+	ErrEntityTypeExists = errors.New("entity type already registered")
+
+	// ErrUnknownScope is returned for scopes that aren't registered
+	ErrUnknownScope = errors.New("unknown scope")
+	// ErrScopeNotAllowed is returned by AllowScopes policies for scopes they reject
+	ErrScopeNotAllowed = errors.New("scope not allowed")
+)
+
+// ParseError describes a prefixed ID that could not be parsed. It matches
+// ErrPrefixMismatch or ErrMalformedID with errors.Is, and unwraps to the
+// underlying parser error, if any.
+type ParseError struct {
+	// EntityType is the entity type the ID was parsed as
+	EntityType string
+	// Prefix is the expected prefix
+	Prefix string
+	// Input is the prefixed ID that was parsed
+	Input string
+	// Kind is ErrPrefixMismatch or ErrMalformedID
+	Kind error
+	// Err is the underlying parser error, nil for prefix mismatches
+	Err error
+}
+
+func (e *ParseError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%v: %s: %q: %v", e.Kind, e.EntityType, e.Input, e.Err)
+	}
+	return fmt.Sprintf("%v: %s: %q does not start with prefix %q", e.Kind, e.EntityType, e.Input, e.Prefix)
+}
+
+// Unwrap returns the error kind and the underlying parser error
+func (e *ParseError) Unwrap() []error {
+	if e.Err != nil {
+		return []error{e.Kind, e.Err}
+	}
+	return []error{e.Kind}
+}
@@ -0,0 +1,153 @@
+package prefixid
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultSeparator joins a prefix and an ID when a Format doesn't set one
+const DefaultSeparator = "_"
+
+// PrefixCase controls case folding of prefixes
+type PrefixCase int
+
+const (
+	// CasePreserve emits prefixes as registered and matches them exactly
+	CasePreserve PrefixCase = iota
+	// CaseLower emits lowercase prefixes and matches them case-insensitively
+	CaseLower
+	// CaseUpper emits uppercase prefixes and matches them case-insensitively
+	CaseUpper
+)
+
+// Format controls how the built-in prefixers join a prefix and an ID.
+// The zero value produces "prefix_id".
+type Format struct {
+	// Separator between the prefix and the ID, DefaultSeparator if empty
+	Separator string
+	// Case folding applied to the prefix
+	Case PrefixCase
+	// Suffix appended after the ID
+	Suffix string
+}
+
+// FormatOption configures a Format
+type FormatOption func(*Format)
+
+// WithSeparator sets the separator between the prefix and the ID
+func WithSeparator(separator string) FormatOption {
+	return func(f *Format) {
+		f.Separator = separator
+	}
+}
+
+// WithPrefixCase sets the case folding applied to the prefix
+func WithPrefixCase(c PrefixCase) FormatOption {
+	return func(f *Format) {
+		f.Case = c
+	}
+}
+
+// WithSuffix sets a suffix appended after the ID
+func WithSuffix(suffix string) FormatOption {
+	return func(f *Format) {
+		f.Suffix = suffix
+	}
+}
+
+// NewFormat creates a Format from options
+func NewFormat(opts ...FormatOption) Format {
+	var f Format
+	for _, opt := range opts {
+		opt(&f)
+	}
+	return f
+}
+
+// Sep returns the separator, falling back to DefaultSeparator
+func (f Format) Sep() string {
+	if f.Separator == "" {
+		return DefaultSeparator
+	}
+	return f.Separator
+}
+
+// Attach joins a prefix and a formatted ID
+func (f Format) Attach(prefix string, id string) string {
+	switch f.Case {
+	case CaseLower:
+		prefix = strings.ToLower(prefix)
+	case CaseUpper:
+		prefix = strings.ToUpper(prefix)
+	}
+	return prefix + f.Sep() + id + f.Suffix
+}
+
+// AppendPrefixed appends the prefixed form of a formatted ID to dst, as
+// Attach would return it
+func (f Format) AppendPrefixed(dst []byte, prefix string, id string) []byte {
+	dst = f.appendHead(dst, prefix)
+	dst = append(dst, id...)
+	return append(dst, f.Suffix...)
+}
+
+// appendHead appends the case-folded prefix and the separator to dst
+func (f Format) appendHead(dst []byte, prefix string) []byte {
+	switch f.Case {
+	case CaseLower:
+		dst = appendFolded(dst, prefix, 'A', strings.ToLower)
+	case CaseUpper:
+		dst = appendFolded(dst, prefix, 'a', strings.ToUpper)
+	default:
+		dst = append(dst, prefix...)
+	}
+	return append(dst, f.Sep()...)
+}
+
+// appendFolded appends s to dst with the ASCII letters from first to
+// first+25 flipped to the other case, falling back to fold for non-ASCII
+// strings
+func appendFolded(dst []byte, s string, first byte, fold func(string) string) []byte {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return append(dst, fold(s)...)
+		}
+	}
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c >= first && c <= first+'z'-'a' {
+			c ^= 'a' - 'A'
+		}
+		dst = append(dst, c)
+	}
+	return dst
+}
+
+// Detach returns the formatted ID from a prefixed ID string
+func (f Format) Detach(prefix string, prefixedID string) (string, bool) {
+	sep := f.Sep()
+	headLen := len(prefix) + len(sep)
+	if len(prefixedID) < headLen+len(f.Suffix) {
+		return "", false
+	}
+
+	if !f.matchHead(prefixedID[:len(prefix)], prefix) || !f.matchHead(prefixedID[len(prefix):headLen], sep) {
+		return "", false
+	}
+
+	rest := prefixedID[headLen:]
+	if !strings.HasSuffix(rest, f.Suffix) {
+		return "", false
+	}
+	return rest[:len(rest)-len(f.Suffix)], true
+}
+
+// matchHead reports whether part of a prefixed ID matches part of its
+// expected prefix under the format's case folding
+func (f Format) matchHead(s, expected string) bool {
+	if f.Case == CasePreserve {
+		return s == expected
+	}
+	return strings.EqualFold(s, expected)
+}
@@ -0,0 +1,156 @@
+package prefixid
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Builder accumulates validated registrations for a FrozenRegistry. Errors
+// are collected and returned together by Build, so a builder can be set up
+// in one chain:
+//
+//	registry, err := prefixid.NewBuilder[string]().
+//		Register("user", "usr", prefixid.StringPrefixer{}).
+//		Register("post", "pst", prefixid.StringPrefixer{}).
+//		Build()
+type Builder[T any] struct {
+	registry *Registry[T]
+	errs     []error
+}
+
+// NewBuilder creates a builder. The options apply to the built registry,
+// and its PrefixPolicy is enforced as registrations are added and on the
+// scopes.
+func NewBuilder[T any](opts ...RegistryOption) *Builder[T] {
+	b := &Builder[T]{registry: NewRegistry[T](opts...)}
+	if b.registry.scopeErr != nil {
+		b.errs = append(b.errs, b.registry.scopeErr)
+	}
+	return b
+}
+
+// Register adds a prefix for a new entity type, validated like
+// Registry.TryRegister
+func (b *Builder[T]) Register(entityType, prefix string, prefixer IDPrefixer[T]) *Builder[T] {
+	if err := b.registry.tryRegister(entityType, prefix, prefixer); err != nil {
+		b.errs = append(b.errs, err)
+	}
+	return b
+}
+
+// RegisterEntry adds a new entity type with a generator or aliases,
+// validated like Registry.Apply
+func (b *Builder[T]) RegisterEntry(entityType string, entry Entry[T]) *Builder[T] {
+	if _, _, ok := b.registry.Lookup(entityType); ok {
+		b.errs = append(b.errs, fmt.Errorf("%w: %s", ErrEntityTypeExists, entityType))
+		return b
+	}
+
+	err := b.registry.update(func(s *registryState[T]) error {
+		return b.registry.apply(s, []Change[T]{{EntityType: entityType, Entry: entry}})
+	})
+	if err != nil {
+		b.errs = append(b.errs, err)
+	}
+	return b
+}
+
+// Build returns a FrozenRegistry with the registrations added so far, or
+// the errors of the registrations that failed. The builder can be used
+// again afterwards without affecting the registry.
+func (b *Builder[T]) Build() (*FrozenRegistry[T], error) {
+	if len(b.errs) > 0 {
+		return nil, errors.Join(b.errs...)
+	}
+
+	s := b.registry.state.Load().clone()
+	s.perfect = newPerfectIndex(s.indexedPrefixes())
+
+	r := &Registry[T]{
+		policy:    b.registry.policy,
+		scopes:    b.registry.scopes,
+		scoping:   b.registry.scoping,
+		aliasHook: b.registry.aliasHook,
+	}
+	r.state.Store(s)
+	return &FrozenRegistry[T]{registry: r}, nil
+}
+
+// MustBuild is like Build but panics on error. It is intended for setting
+// up registries at init time.
+func (b *Builder[T]) MustBuild() *FrozenRegistry[T] {
+	registry, err := b.Build()
+	if err != nil {
+		panic(err)
+	}
+	return registry
+}
+
+// FrozenRegistry is a registry that cannot be changed once built by a
+// Builder. It has the read methods of Registry, and it matches prefixes
+// with a perfect hash table instead of a trie.
+type FrozenRegistry[T any] struct {
+	// registry is never written to after Build
+	registry *Registry[T]
+}
+
+// GetEntityTypes returns all registered entity types
+func (f *FrozenRegistry[T]) GetEntityTypes() []string {
+	return f.registry.GetEntityTypes()
+}
+
+// Lookup is like Registry.Lookup
+func (f *FrozenRegistry[T]) Lookup(entityType string) (string, IDPrefixer[T], bool) {
+	return f.registry.Lookup(entityType)
+}
+
+// Aliases returns the aliases of an entity type
+func (f *FrozenRegistry[T]) Aliases(entityType string) []string {
+	return f.registry.Aliases(entityType)
+}
+
+// Scopes returns the registry's scopes
+func (f *FrozenRegistry[T]) Scopes() []string {
+	return f.registry.Scopes()
+}
+
+// PrefixID creates a prefixed ID string for an entity type and ID
+func (f *FrozenRegistry[T]) PrefixID(entityType string, id T) (string, error) {
+	return f.registry.PrefixID(entityType, id)
+}
+
+// AppendPrefixID is like Registry.AppendPrefixID
+func (f *FrozenRegistry[T]) AppendPrefixID(dst []byte, entityType string, id T) ([]byte, error) {
+	return f.registry.AppendPrefixID(dst, entityType, id)
+}
+
+// PrefixScopedID is like Registry.PrefixScopedID
+func (f *FrozenRegistry[T]) PrefixScopedID(entityType, scope string, id T) (string, error) {
+	return f.registry.PrefixScopedID(entityType, scope, id)
+}
+
+// New is like Registry.New
+func (f *FrozenRegistry[T]) New(entityType string) (string, T, error) {
+	return f.registry.New(entityType)
+}
+
+// ParsePrefixedID is like Registry.ParsePrefixedID
+func (f *FrozenRegistry[T]) ParsePrefixedID(entityType, prefixedID string) (T, error) {
+	return f.registry.ParsePrefixedID(entityType, prefixedID)
+}
+
+// ParseScopedID is like Registry.ParseScopedID
+func (f *FrozenRegistry[T]) ParseScopedID(entityType, prefixedID string) (string, T, error) {
+	return f.registry.ParseScopedID(entityType, prefixedID)
+}
+
+// MatchPrefix tries to determine the entity type from a prefixed ID, by
+// longest prefix like Registry.MatchPrefix
+func (f *FrozenRegistry[T]) MatchPrefix(prefixedID string) (string, string, bool) {
+	return f.registry.MatchPrefix(prefixedID)
+}
+
+// Resolve is like Registry.Resolve
+func (f *FrozenRegistry[T]) Resolve(prefixedID string) (string, T, error) {
+	return f.registry.Resolve(prefixedID)
+}
@@ -0,0 +1,81 @@
+package prefixid
+
+import (
+	"crypto/rand"
+	"sync"
+
+	"github.com/google/uuid"
+	"github.com/oklog/ulid/v2"
+	"github.com/segmentio/ksuid"
+)
+
+// Generator generates new IDs for Registry.New
+type Generator[T any] interface {
+	// Generate returns a new ID
+	Generate() (T, error)
+}
+
+// GeneratorFunc adapts a function to the Generator interface
+type GeneratorFunc[T any] func() (T, error)
+
+// Generate calls f
+func (f GeneratorFunc[T]) Generate() (T, error) {
+	return f()
+}
+
+// UUIDv4Generator generates random (version 4) UUIDs
+type UUIDv4Generator struct{}
+
+var _ Generator[uuid.UUID] = UUIDv4Generator{}
+
+// Generate returns a new version 4 UUID
+func (g UUIDv4Generator) Generate() (uuid.UUID, error) {
+	return uuid.NewRandom()
+}
+
+// UUIDv7Generator generates time-ordered (version 7) UUIDs
+type UUIDv7Generator struct{}
+
+var _ Generator[uuid.UUID] = UUIDv7Generator{}
+
+// Generate returns a new version 7 UUID
+func (g UUIDv7Generator) Generate() (uuid.UUID, error) {
+	return uuid.NewV7()
+}
+
+// ULIDGenerator generates ULIDs with monotonic entropy, so ULIDs generated
+// within the same millisecond are strictly increasing. It is safe for
+// concurrent use, and the zero value is ready to use.
+type ULIDGenerator struct {
+	mutex   sync.Mutex
+	entropy *ulid.MonotonicEntropy
+}
+
+var _ Generator[ulid.ULID] = (*ULIDGenerator)(nil)
+
+// NewULIDGenerator creates a ULIDGenerator
+func NewULIDGenerator() *ULIDGenerator {
+	return &ULIDGenerator{
+		entropy: ulid.Monotonic(rand.Reader, 0),
+	}
+}
+
+// Generate returns a new ULID
+func (g *ULIDGenerator) Generate() (ulid.ULID, error) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	if g.entropy == nil {
+		g.entropy = ulid.Monotonic(rand.Reader, 0)
+	}
+	return ulid.New(ulid.Now(), g.entropy)
+}
+
+// KSUIDGenerator generates KSUIDs
+type KSUIDGenerator struct{}
+
+var _ Generator[ksuid.KSUID] = KSUIDGenerator{}
+
+// Generate returns a new KSUID
+func (g KSUIDGenerator) Generate() (ksuid.KSUID, error) {
+	return ksuid.NewRandom()
+}
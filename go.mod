@@ -8,6 +8,7 @@ require (
 	github.com/google/uuid v1.6.0
 	github.com/oklog/ulid/v2 v2.1.0
 	github.com/segmentio/ksuid v1.0.4
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 // Indicate that all versions are deprecated
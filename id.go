@@ -0,0 +1,206 @@
+package prefixid
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/google/uuid"
+	"github.com/oklog/ulid/v2"
+	"github.com/segmentio/ksuid"
+)
+
+// Entity is implemented by zero-size marker types that name an entity and
+// carry its prefix, e.g.
+//
+//	type User struct{}
+//
+//	func (User) Prefix() string { return "usr" }
+type Entity interface {
+	// Prefix returns the prefix for the entity
+	Prefix() string
+}
+
+// EntityPrefixer can be implemented by an Entity to choose the IDPrefixer
+// used for its IDs. Entities that don't implement it use the built-in
+// prefixer for T (string, int, uuid.UUID, ulid.ULID or ksuid.KSUID).
+type EntityPrefixer[T any] interface {
+	// Prefixer returns the IDPrefixer for the entity's IDs
+	Prefixer() IDPrefixer[T]
+}
+
+// ID is a prefixed ID of entity E with an underlying ID of type T.
+// ID[User, uuid.UUID] and ID[Order, uuid.UUID] are distinct types, so one
+// cannot be passed where the other is expected.
+type ID[E Entity, T any] struct {
+	id T
+}
+
+// NewID wraps an underlying ID as an ID of entity E
+func NewID[E Entity, T any](id T) ID[E, T] {
+	return ID[E, T]{id: id}
+}
+
+// ParseID parses a prefixed ID string into an ID of entity E
+func ParseID[E Entity, T any](s string) (ID[E, T], error) {
+	var e E
+
+	prefixer, ok := entityPrefixer[E, T]()
+	if !ok {
+		return ID[E, T]{}, fmt.Errorf("%w: %T", ErrNoPrefixer, e)
+	}
+
+	rawStr, ok := prefixer.Detach(e.Prefix(), s)
+	if !ok {
+		return ID[E, T]{}, detachError(prefixer, entityName(e), e.Prefix(), s)
+	}
+
+	id, err := prefixer.Parse(rawStr)
+	if err != nil {
+		return ID[E, T]{}, &ParseError{EntityType: entityName(e), Prefix: e.Prefix(), Input: s, Kind: ErrMalformedID, Err: err}
+	}
+	return ID[E, T]{id: id}, nil
+}
+
+// MustParseID is like ParseID but panics if the string cannot be parsed
+func MustParseID[E Entity, T any](s string) ID[E, T] {
+	id, err := ParseID[E, T](s)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// Raw returns the underlying ID
+func (i ID[E, T]) Raw() T {
+	return i.id
+}
+
+// Prefix returns the prefix of entity E
+func (i ID[E, T]) Prefix() string {
+	var e E
+	return e.Prefix()
+}
+
+// IsZero reports whether the underlying ID is the zero value of T
+func (i ID[E, T]) IsZero() bool {
+	return reflect.ValueOf(&i.id).Elem().IsZero()
+}
+
+// String returns the prefixed ID string
+func (i ID[E, T]) String() string {
+	prefixer, ok := entityPrefixer[E, T]()
+	if !ok {
+		return fmt.Sprintf("%s_%v", i.Prefix(), i.id)
+	}
+	return prefixer.Attach(i.Prefix(), i.id)
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (i ID[E, T]) MarshalText() ([]byte, error) {
+	return i.AppendText(nil)
+}
+
+// AppendText implements encoding.TextAppender. IDs using a built-in
+// prefixer are appended without allocating.
+func (i ID[E, T]) AppendText(b []byte) ([]byte, error) {
+	var e E
+	if ep, ok := any(e).(EntityPrefixer[T]); ok {
+		// Custom prefixers may embed a built-in prefixer and override only
+		// Attach, so its AppendPrefixed can't be trusted
+		return append(b, ep.Prefixer().Attach(i.Prefix(), i.id)...), nil
+	}
+
+	prefixer, ok := builtinPrefixer[T]()
+	if !ok {
+		return b, fmt.Errorf("%w: %T", ErrNoPrefixer, e)
+	}
+	return prefixer.(Appender[T]).AppendPrefixed(b, i.Prefix(), i.id), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler. The prefix and the
+// underlying ID are validated by the entity's prefixer.
+func (i *ID[E, T]) UnmarshalText(text []byte) error {
+	id, err := ParseID[E, T](string(text))
+	if err != nil {
+		return err
+	}
+	*i = id
+	return nil
+}
+
+// MarshalJSON implements json.Marshaler, encoding the ID as a JSON string
+func (i ID[E, T]) MarshalJSON() ([]byte, error) {
+	text, err := i.MarshalText()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(string(text))
+}
+
+// UnmarshalJSON implements json.Unmarshaler. A JSON null leaves the ID
+// unchanged.
+func (i *ID[E, T]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	return i.UnmarshalText([]byte(s))
+}
+
+// entityName returns the name of an entity marker type for error messages
+func entityName(e Entity) string {
+	return reflect.TypeOf(e).Name()
+}
+
+// entityPrefixer returns the IDPrefixer for IDs of entity E
+func entityPrefixer[E Entity, T any]() (IDPrefixer[T], bool) {
+	var e E
+	if ep, ok := any(e).(EntityPrefixer[T]); ok {
+		return ep.Prefixer(), true
+	}
+	return builtinPrefixer[T]()
+}
+
+// builtinPrefixer returns the built-in IDPrefixer for T, if there is one
+func builtinPrefixer[T any]() (IDPrefixer[T], bool) {
+	var zero T
+	var prefixer any
+	switch any(zero).(type) {
+	case string:
+		prefixer = StringPrefixer{}
+	case int:
+		prefixer = IntPrefixer{}
+	case int8:
+		prefixer = IntegerPrefixer[int8]{}
+	case int16:
+		prefixer = IntegerPrefixer[int16]{}
+	case int32:
+		prefixer = IntegerPrefixer[int32]{}
+	case int64:
+		prefixer = IntegerPrefixer[int64]{}
+	case uint:
+		prefixer = IntegerPrefixer[uint]{}
+	case uint8:
+		prefixer = IntegerPrefixer[uint8]{}
+	case uint16:
+		prefixer = IntegerPrefixer[uint16]{}
+	case uint32:
+		prefixer = IntegerPrefixer[uint32]{}
+	case uint64:
+		prefixer = IntegerPrefixer[uint64]{}
+	case uuid.UUID:
+		prefixer = UUIDPrefixer{}
+	case ulid.ULID:
+		prefixer = ULIDPrefixer{}
+	case ksuid.KSUID:
+		prefixer = KSUIDPrefixer{}
+	default:
+		return nil, false
+	}
+	return prefixer.(IDPrefixer[T]), true
+}
@@ -1,28 +1,39 @@
 package prefixid
 
-import (
-	"fmt"
-	"strconv"
-	"strings"
-)
+import "strconv"
 
 // IntPrefixer implements IDPrefixer for int IDs
-type IntPrefixer struct{}
+type IntPrefixer struct {
+	// Format controls how the prefix and ID are joined
+	Format Format
+}
 
-var _ IDPrefixer[int] = IntPrefixer{}
+var (
+	_ IDPrefixer[int] = IntPrefixer{}
+	_ Appender[int]   = IntPrefixer{}
+)
+
+// NewIntPrefixer creates a IntPrefixer with the given format options
+func NewIntPrefixer(opts ...FormatOption) IntPrefixer {
+	return IntPrefixer{Format: NewFormat(opts...)}
+}
 
 // Attach attaches a prefix to an int ID
 func (p IntPrefixer) Attach(prefix string, id int) string {
-	return fmt.Sprintf("%s_%d", prefix, id)
+	var buf [64]byte
+	return string(p.AppendPrefixed(buf[:0], prefix, id))
+}
+
+// AppendPrefixed appends a prefixed int ID to dst
+func (p IntPrefixer) AppendPrefixed(dst []byte, prefix string, id int) []byte {
+	dst = p.Format.appendHead(dst, prefix)
+	dst = strconv.AppendInt(dst, int64(id), 10)
+	return append(dst, p.Format.Suffix...)
 }
 
 // Detach detaches a prefix from a prefixed ID string
 func (p IntPrefixer) Detach(prefix string, prefixedID string) (string, bool) {
-	expectedPrefix := fmt.Sprintf("%s_", prefix)
-	if strings.HasPrefix(prefixedID, expectedPrefix) {
-		return strings.TrimPrefix(prefixedID, expectedPrefix), true
-	}
-	return "", false
+	return p.Format.Detach(prefix, prefixedID)
 }
 
 // Parse parses a string into an int ID
@@ -0,0 +1,192 @@
+package prefixid
+
+import (
+	"strconv"
+	"unsafe"
+)
+
+// Integer is the set of integer types supported by IntegerPrefixer
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64
+}
+
+// IntegerEncoding selects the digits IntegerPrefixer uses for the ID
+type IntegerEncoding int
+
+const (
+	// IntegerDecimal encodes IDs in base 10, e.g. 1234567
+	IntegerDecimal IntegerEncoding = iota
+	// IntegerBase36 encodes IDs with 0-9 and a-z, e.g. qglj
+	IntegerBase36
+	// IntegerBase62 encodes IDs with 0-9, A-Z and a-z, e.g. 5BAN
+	IntegerBase62
+)
+
+var (
+	base10Codec = newBaseCodec("0123456789")
+	base36Codec = newBaseCodec("0123456789abcdefghijklmnopqrstuvwxyz")
+)
+
+// codec returns the digits of the encoding
+func (e IntegerEncoding) codec() *baseCodec {
+	switch e {
+	case IntegerBase36:
+		return base36Codec
+	case IntegerBase62:
+		return base62Codec
+	default:
+		return base10Codec
+	}
+}
+
+// IntegerPrefixer implements IDPrefixer for any integer type.
+//
+// Unlike IntPrefixer, Parse only accepts the canonical form produced by
+// Attach: no "+" sign, no leading zeros and no "-0", and values must fit T.
+type IntegerPrefixer[T Integer] struct {
+	// Format controls how the prefix and ID are joined
+	Format Format
+	// Encoding of the ID, IntegerDecimal if unset
+	Encoding IntegerEncoding
+}
+
+var _ IDPrefixer[int64] = IntegerPrefixer[int64]{}
+
+// NewIntegerPrefixer creates an IntegerPrefixer with the given format options
+func NewIntegerPrefixer[T Integer](opts ...FormatOption) IntegerPrefixer[T] {
+	return IntegerPrefixer[T]{Format: NewFormat(opts...)}
+}
+
+// Attach attaches a prefix to an integer ID
+func (p IntegerPrefixer[T]) Attach(prefix string, id T) string {
+	var buf [96]byte
+	return string(p.AppendPrefixed(buf[:0], prefix, id))
+}
+
+// AppendPrefixed appends a prefixed integer ID to dst
+func (p IntegerPrefixer[T]) AppendPrefixed(dst []byte, prefix string, id T) []byte {
+	dst = p.Format.appendHead(dst, prefix)
+	dst = appendInteger(dst, id, p.Encoding.codec())
+	return append(dst, p.Format.Suffix...)
+}
+
+// Detach detaches a prefix from a prefixed ID string
+func (p IntegerPrefixer[T]) Detach(prefix string, prefixedID string) (string, bool) {
+	return p.Format.Detach(prefix, prefixedID)
+}
+
+// Parse parses a canonically encoded string into an integer ID. Errors are
+// *strconv.NumError values wrapping strconv.ErrSyntax or strconv.ErrRange.
+func (p IntegerPrefixer[T]) Parse(s string) (T, error) {
+	return parseInteger[T](s, p.Encoding.codec())
+}
+
+// integerInfo returns whether T is signed and its size in bits
+func integerInfo[T Integer]() (bool, int) {
+	var zero T
+	return ^zero < 0, int(unsafe.Sizeof(zero)) * 8
+}
+
+// appendInteger appends an integer in the codec's digits to dst
+func appendInteger[T Integer](dst []byte, id T, codec *baseCodec) []byte {
+	signed, _ := integerInfo[T]()
+
+	var magnitude uint64
+	if signed && id < 0 {
+		dst = append(dst, '-')
+		// Negate in uint64 so the minimum value doesn't overflow
+		magnitude = uint64(-(int64(id) + 1)) + 1
+	} else {
+		magnitude = uint64(id)
+	}
+	return appendUint(dst, magnitude, codec)
+}
+
+// appendUint appends an unsigned integer in the codec's digits to dst
+func appendUint(dst []byte, n uint64, codec *baseCodec) []byte {
+	var buf [64]byte
+	base := uint64(codec.base())
+
+	i := len(buf)
+	for {
+		i--
+		buf[i] = codec.alphabet[n%base]
+		n /= base
+		if n == 0 {
+			break
+		}
+	}
+	return append(dst, buf[i:]...)
+}
+
+// parseInteger strictly parses an integer in the codec's digits
+func parseInteger[T Integer](s string, codec *baseCodec) (T, error) {
+	const fn = "ParseInteger"
+	signed, bits := integerInfo[T]()
+
+	digits := s
+	negative := false
+	if signed && len(digits) > 0 && digits[0] == '-' {
+		negative = true
+		digits = digits[1:]
+	}
+
+	magnitude, err := parseUint(digits, codec)
+	if err == nil && negative && magnitude == 0 {
+		err = strconv.ErrSyntax
+	}
+	if err != nil {
+		return 0, &strconv.NumError{Func: fn, Num: s, Err: err}
+	}
+
+	var limit uint64
+	switch {
+	case !signed:
+		limit = 1<<bits - 1
+	case negative:
+		limit = 1 << (bits - 1)
+	default:
+		limit = 1<<(bits-1) - 1
+	}
+
+	if magnitude > limit {
+		return 0, &strconv.NumError{Func: fn, Num: s, Err: strconv.ErrRange}
+	}
+
+	if negative {
+		// -(magnitude) computed without overflowing the minimum value
+		return T(-int64(magnitude-1) - 1), nil
+	}
+	return T(magnitude), nil
+}
+
+// parseUint parses a canonical unsigned integer: at least one digit, no
+// leading zeros, no sign. It returns strconv.ErrSyntax or strconv.ErrRange.
+func parseUint(s string, codec *baseCodec) (uint64, error) {
+	if s == "" || (len(s) > 1 && s[0] == codec.alphabet[0]) {
+		return 0, strconv.ErrSyntax
+	}
+
+	base := uint64(codec.base())
+	var n uint64
+	overflow := false
+	for i := 0; i < len(s); i++ {
+		digit := codec.decode[s[i]]
+		if digit == invalidDigit {
+			return 0, strconv.ErrSyntax
+		}
+
+		if overflow || n > (^uint64(0)-uint64(digit))/base {
+			// Keep checking the remaining digits so syntax errors win
+			overflow = true
+			continue
+		}
+		n = n*base + uint64(digit)
+	}
+
+	if overflow {
+		return 0, strconv.ErrRange
+	}
+	return n, nil
+}
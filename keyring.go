@@ -0,0 +1,124 @@
+package prefixid
+
+import (
+	"crypto/cipher"
+	"fmt"
+	"sync"
+)
+
+// MinKeySize is the smallest key, in bytes, a Keyring accepts
+const MinKeySize = 16
+
+// Keyring holds the secret keys of SignedPrefixer and the encrypted
+// prefixers, identified by single character key IDs that are embedded in
+// IDs. New IDs use the current key while every key in the ring is accepted,
+// so keys can be rotated by adding a new key, making it current and removing
+// the old one once the IDs it produced have expired.
+type Keyring struct {
+	keys map[byte][]byte
+	// ciphers holds the block cipher of each key, derived once in Add
+	ciphers map[byte]cipher.Block
+	current byte
+	mutex   sync.RWMutex
+}
+
+// NewKeyring creates a keyring with a single, current key. Key IDs are
+// ASCII letters or digits.
+func NewKeyring(keyID byte, key []byte) (*Keyring, error) {
+	k := &Keyring{
+		keys:    make(map[byte][]byte),
+		ciphers: make(map[byte]cipher.Block),
+	}
+	if err := k.Add(keyID, key); err != nil {
+		return nil, err
+	}
+	k.current = keyID
+	return k, nil
+}
+
+// Add adds a key that verifies IDs but doesn't produce them until Use is
+// called
+func (k *Keyring) Add(keyID byte, key []byte) error {
+	if base62Codec.decode[keyID] == invalidDigit {
+		return fmt.Errorf("%w: key ID %q is not a letter or digit", ErrInvalidKey, keyID)
+	}
+
+	if len(key) < MinKeySize {
+		return fmt.Errorf("%w: key %q is shorter than %d bytes", ErrInvalidKey, keyID, MinKeySize)
+	}
+
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+
+	if _, ok := k.keys[keyID]; ok {
+		return fmt.Errorf("%w: key ID %q is already used", ErrInvalidKey, keyID)
+	}
+	k.keys[keyID] = append([]byte(nil), key...)
+	k.ciphers[keyID] = blockCipher(key)
+	return nil
+}
+
+// Use makes a key the current key
+func (k *Keyring) Use(keyID byte) error {
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+
+	if _, ok := k.keys[keyID]; !ok {
+		return fmt.Errorf("%w: unknown key ID %q", ErrInvalidKey, keyID)
+	}
+	k.current = keyID
+	return nil
+}
+
+// Remove removes a key, so IDs it produced no longer verify. The current
+// key cannot be removed.
+func (k *Keyring) Remove(keyID byte) error {
+	k.mutex.Lock()
+	defer k.mutex.Unlock()
+
+	if keyID == k.current {
+		return fmt.Errorf("%w: key ID %q is the current key", ErrInvalidKey, keyID)
+	}
+	delete(k.keys, keyID)
+	delete(k.ciphers, keyID)
+	return nil
+}
+
+// Current returns the ID of the current key
+func (k *Keyring) Current() byte {
+	k.mutex.RLock()
+	defer k.mutex.RUnlock()
+	return k.current
+}
+
+// currentKey returns the current key and its ID
+func (k *Keyring) currentKey() (byte, []byte) {
+	k.mutex.RLock()
+	defer k.mutex.RUnlock()
+	return k.current, k.keys[k.current]
+}
+
+// key returns the key with the given ID
+func (k *Keyring) key(keyID byte) ([]byte, bool) {
+	k.mutex.RLock()
+	defer k.mutex.RUnlock()
+
+	key, ok := k.keys[keyID]
+	return key, ok
+}
+
+// currentCipher returns the block cipher of the current key and its ID
+func (k *Keyring) currentCipher() (byte, cipher.Block) {
+	k.mutex.RLock()
+	defer k.mutex.RUnlock()
+	return k.current, k.ciphers[k.current]
+}
+
+// keyCipher returns the block cipher of the key with the given ID
+func (k *Keyring) keyCipher(keyID byte) (cipher.Block, bool) {
+	k.mutex.RLock()
+	defer k.mutex.RUnlock()
+
+	c, ok := k.ciphers[keyID]
+	return c, ok
+}
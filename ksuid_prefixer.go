@@ -1,29 +1,39 @@
 package prefixid
 
-import (
-	"fmt"
-	"strings"
-
-	"github.com/segmentio/ksuid"
-)
+import "github.com/segmentio/ksuid"
 
 // KSUIDPrefixer implements IDPrefixer for KSUID IDs
-type KSUIDPrefixer struct{}
+type KSUIDPrefixer struct {
+	// Format controls how the prefix and ID are joined
+	Format Format
+}
+
+var (
+	_ IDPrefixer[ksuid.KSUID] = KSUIDPrefixer{}
+	_ Appender[ksuid.KSUID]   = KSUIDPrefixer{}
+)
 
-var _ IDPrefixer[ksuid.KSUID] = KSUIDPrefixer{}
+// NewKSUIDPrefixer creates a KSUIDPrefixer with the given format options
+func NewKSUIDPrefixer(opts ...FormatOption) KSUIDPrefixer {
+	return KSUIDPrefixer{Format: NewFormat(opts...)}
+}
 
 // Attach attaches a prefix to a KSUID ID
 func (p KSUIDPrefixer) Attach(prefix string, id ksuid.KSUID) string {
-	return fmt.Sprintf("%s_%s", prefix, id.String())
+	var buf [64]byte
+	return string(p.AppendPrefixed(buf[:0], prefix, id))
+}
+
+// AppendPrefixed appends a prefixed KSUID ID to dst
+func (p KSUIDPrefixer) AppendPrefixed(dst []byte, prefix string, id ksuid.KSUID) []byte {
+	dst = p.Format.appendHead(dst, prefix)
+	dst = id.Append(dst)
+	return append(dst, p.Format.Suffix...)
 }
 
 // Detach detaches a prefix from a prefixed ID string
 func (p KSUIDPrefixer) Detach(prefix string, prefixedID string) (string, bool) {
-	expectedPrefix := fmt.Sprintf("%s_", prefix)
-	if strings.HasPrefix(prefixedID, expectedPrefix) {
-		return strings.TrimPrefix(prefixedID, expectedPrefix), true
-	}
-	return "", false
+	return p.Format.Detach(prefix, prefixedID)
 }
 
 // Parse parses a string into a KSUID
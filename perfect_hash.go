@@ -0,0 +1,179 @@
+package prefixid
+
+import (
+	"slices"
+	"sort"
+)
+
+// perfectIndex is the prefix index of a FrozenRegistry, an alternative to
+// prefixTrie for a fixed set of prefixes. Prefixes are folded to lowercase
+// ASCII and placed in a table by a perfect hash built by hash and displace:
+// each prefix hashes into a bucket, and every bucket has a seed chosen so
+// its prefixes land in free slots. A lookup is two hashes and one
+// comparison per distinct prefix length.
+type perfectIndex struct {
+	seeds []uint32
+	slots []perfectSlot
+	// lengths of the prefixes, longest first
+	lengths []int
+}
+
+// perfectSlot holds the entries of a folded prefix, sorted by entity type.
+// Free slots have no entries.
+type perfectSlot struct {
+	key     string
+	entries []trieEntry
+}
+
+// maxSeed bounds the search for a bucket seed before the table is grown
+const maxSeed = 1 << 16
+
+// newPerfectIndex builds a perfect hash index of entries
+func newPerfectIndex(entries []trieEntry) *perfectIndex {
+	groups := make(map[string][]trieEntry)
+	for _, entry := range entries {
+		key := foldASCII(entry.prefix)
+		groups[key] = append(groups[key], entry)
+	}
+
+	keys := make([]string, 0, len(groups))
+	lengths := make([]int, 0, len(groups))
+	for key, group := range groups {
+		sort.Slice(group, func(i, j int) bool {
+			return group[i].entityType < group[j].entityType
+		})
+		keys = append(keys, key)
+		lengths = append(lengths, len(key))
+	}
+	slices.Sort(keys)
+	slices.Sort(lengths)
+	slices.Reverse(lengths)
+
+	size := 1
+	for size < 2*len(keys) {
+		size <<= 1
+	}
+	for {
+		if idx, ok := buildPerfectIndex(keys, groups, size); ok {
+			idx.lengths = slices.Compact(lengths)
+			return idx
+		}
+		size <<= 1
+	}
+}
+
+// buildPerfectIndex places keys in a table of size slots, failing if some
+// bucket has no seed that fits
+func buildPerfectIndex(keys []string, groups map[string][]trieEntry, size int) (*perfectIndex, bool) {
+	buckets := make([][]string, max(size/4, 1))
+	for _, key := range keys {
+		b := hashFold(0, key) & uint32(len(buckets)-1)
+		buckets[b] = append(buckets[b], key)
+	}
+
+	// Place the largest buckets while the table is emptiest
+	order := make([]int, len(buckets))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return len(buckets[order[i]]) > len(buckets[order[j]])
+	})
+
+	idx := &perfectIndex{
+		seeds: make([]uint32, len(buckets)),
+		slots: make([]perfectSlot, size),
+	}
+	mask := uint32(size - 1)
+	placed := make([]uint32, 0, 8)
+
+	for _, b := range order {
+		bucket := buckets[b]
+		if len(bucket) == 0 {
+			continue
+		}
+
+		seed := uint32(1)
+	search:
+		for ; seed < maxSeed; seed++ {
+			placed = placed[:0]
+			for _, key := range bucket {
+				slot := hashFold(seed, key) & mask
+				if idx.slots[slot].entries != nil || slices.Contains(placed, slot) {
+					continue search
+				}
+				placed = append(placed, slot)
+			}
+			break
+		}
+		if seed == maxSeed {
+			return nil, false
+		}
+
+		idx.seeds[b] = seed
+		for i, key := range bucket {
+			idx.slots[placed[i]] = perfectSlot{key: key, entries: groups[key]}
+		}
+	}
+	return idx, true
+}
+
+// match calls fn for each entry whose prefix is a prefix of s, like
+// prefixTrie.match
+func (idx *perfectIndex) match(s string, fn func(entry *trieEntry) bool) bool {
+	for _, n := range idx.lengths {
+		if n > len(s) {
+			continue
+		}
+
+		head := s[:n]
+		seed := idx.seeds[hashFold(0, head)&uint32(len(idx.seeds)-1)]
+		slot := &idx.slots[hashFold(seed, head)&uint32(len(idx.slots)-1)]
+		if slot.entries == nil || len(slot.key) != n || !equalFoldASCII(slot.key, head) {
+			continue
+		}
+
+		for i := range slot.entries {
+			if fn(&slot.entries[i]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// hashFold hashes s folded to lowercase ASCII with FNV-1a, seeded and
+// finalized so that the low bits used to index tables are well mixed
+func hashFold(seed uint32, s string) uint32 {
+	const prime = 16777619
+	h := (2166136261 ^ seed) * prime
+	for i := 0; i < len(s); i++ {
+		h ^= uint32(lowerASCII(s[i]))
+		h *= prime
+	}
+
+	h ^= h >> 16
+	h *= 0x85ebca6b
+	h ^= h >> 13
+	return h
+}
+
+// foldASCII returns s with ASCII letters folded to lowercase
+func foldASCII(s string) string {
+	folded := []byte(s)
+	for i, c := range folded {
+		folded[i] = lowerASCII(c)
+	}
+	return string(folded)
+}
+
+// equalFoldASCII reports whether a and b, of equal length, are equal with
+// ASCII letters folded to lowercase
+func equalFoldASCII(a, b string) bool {
+	for i := 0; i < len(a); i++ {
+		if lowerASCII(a[i]) != lowerASCII(b[i]) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,97 @@
+package prefixid
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+)
+
+// DefaultPrefixCharset is the character set allowed by DefaultPrefixPolicy
+const DefaultPrefixCharset = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+// PrefixPolicy describes the prefixes accepted by Registry.TryRegister
+type PrefixPolicy struct {
+	// MinLength is the minimum prefix length; prefixes are never empty
+	MinLength int
+	// MaxLength is the maximum prefix length, 0 for no limit
+	MaxLength int
+	// Charset lists the characters allowed in a prefix, empty for any
+	Charset string
+	// Separator may not appear in a prefix, DefaultSeparator if empty
+	Separator string
+	// AllowNested allows a prefix to be a strict prefix of another, such
+	// as "us" and "usr". MatchPrefix resolves these by longest match.
+	AllowNested bool
+	// Check, if set, is run after the other checks, for rules the fields
+	// can't express
+	Check func(prefix string) error
+}
+
+// DefaultPrefixPolicy is used by registries created without WithPrefixPolicy
+var DefaultPrefixPolicy = PrefixPolicy{
+	MinLength: 1,
+	MaxLength: 32,
+	Charset:   DefaultPrefixCharset,
+}
+
+// Validate checks a single prefix against the policy
+func (p PrefixPolicy) Validate(prefix string) error {
+	if prefix == "" {
+		return fmt.Errorf("%w: prefix is empty", ErrInvalidPrefix)
+	}
+
+	if len(prefix) < p.MinLength {
+		return fmt.Errorf("%w: %q is shorter than %d characters", ErrInvalidPrefix, prefix, p.MinLength)
+	}
+
+	if p.MaxLength > 0 && len(prefix) > p.MaxLength {
+		return fmt.Errorf("%w: %q is longer than %d characters", ErrInvalidPrefix, prefix, p.MaxLength)
+	}
+
+	separator := p.Separator
+	if separator == "" {
+		separator = DefaultSeparator
+	}
+	if strings.Contains(prefix, separator) {
+		return fmt.Errorf("%w: %q contains the separator %q", ErrInvalidPrefix, prefix, separator)
+	}
+
+	if p.Charset != "" {
+		for _, c := range prefix {
+			if !strings.ContainsRune(p.Charset, c) {
+				return fmt.Errorf("%w: %q contains %q, allowed characters are %q", ErrInvalidPrefix, prefix, c, p.Charset)
+			}
+		}
+	}
+
+	if p.Check != nil {
+		if err := p.Check(prefix); err != nil {
+			return fmt.Errorf("%w: %w", ErrInvalidPrefix, err)
+		}
+	}
+
+	return nil
+}
+
+// checkConflicts checks a prefix for an entity type against the prefixes of
+// other entity types
+func (p PrefixPolicy) checkConflicts(entityType, prefix string, prefixes iter.Seq2[string, string]) error {
+	for otherType, otherPrefix := range prefixes {
+		if otherType == entityType {
+			continue
+		}
+
+		if otherPrefix == prefix {
+			return fmt.Errorf("%w: %q is already used by entity type %s", ErrDuplicatePrefix, prefix, otherType)
+		}
+
+		if p.AllowNested {
+			continue
+		}
+
+		if strings.HasPrefix(otherPrefix, prefix) || strings.HasPrefix(prefix, otherPrefix) {
+			return fmt.Errorf("%w: %q overlaps %q used by entity type %s", ErrPrefixOverlap, prefix, otherPrefix, otherType)
+		}
+	}
+	return nil
+}
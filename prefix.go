@@ -2,7 +2,10 @@ package prefixid
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 	"sync"
+	"sync/atomic"
 )
 
 // Generic IDPrefixer interface
@@ -15,103 +18,511 @@ type IDPrefixer[T any] interface {
 	Parse(s string) (T, error)
 }
 
+// Appender is implemented by prefixers that can append a prefixed ID to a
+// byte slice, as Attach would return it, without allocating
+type Appender[T any] interface {
+	// AppendPrefixed appends the prefixed ID to dst
+	AppendPrefixed(dst []byte, prefix string, id T) []byte
+}
+
+// Verifier is implemented by prefixers that authenticate prefixed IDs, like
+// SignedPrefixer. When such a prefixer fails to detach an ID, Verify tells
+// a forged or tampered ID apart from one with the wrong prefix.
+type Verifier interface {
+	// Verify returns an error if the prefixed ID has the prefix but fails
+	// authentication, and nil if it has another prefix
+	Verify(prefix string, prefixedID string) error
+}
+
+// detachError returns the *ParseError for a prefixed ID a prefixer failed
+// to detach: ErrMalformedID if it has the prefix but fails verification,
+// and ErrPrefixMismatch otherwise
+func detachError(prefixer any, entityType, prefix, prefixedID string) error {
+	if verifier, ok := prefixer.(Verifier); ok {
+		if err := verifier.Verify(prefix, prefixedID); err != nil {
+			return &ParseError{EntityType: entityType, Prefix: prefix, Input: prefixedID, Kind: ErrMalformedID, Err: err}
+		}
+	}
+	return &ParseError{EntityType: entityType, Prefix: prefix, Input: prefixedID, Kind: ErrPrefixMismatch}
+}
+
 // Generic Registry
 type Registry[T any] struct {
-	prefixes  map[string]string
-	prefixers map[string]IDPrefixer[T]
-	mutex     sync.RWMutex
+	// state is the current snapshot of the registrations, read without
+	// locking and replaced as a whole by writers
+	state     atomic.Pointer[registryState[T]]
+	policy    PrefixPolicy
+	scopes    []string
+	scoping   ScopePolicy
+	aliasHook AliasHook
+	// scopeErr reports invalid scopes, returned by the methods that
+	// validate registrations
+	scopeErr error
+	// mutex serializes writers
+	mutex sync.Mutex
 }
 
-// NewRegistry creates a new prefix registry
-func NewRegistry[T any]() *Registry[T] {
-	return &Registry[T]{
-		prefixes:  make(map[string]string),
-		prefixers: make(map[string]IDPrefixer[T]),
+// registryState is an immutable snapshot of a registry's registrations
+type registryState[T any] struct {
+	prefixes   map[string]string
+	prefixers  map[string]IDPrefixer[T]
+	generators map[string]Generator[T]
+	aliases    map[string][]string
+	// scopes are the registry's scopes, indexed with every prefix and alias
+	scopes []string
+	trie   *prefixTrie
+	// perfect replaces trie in the state of a FrozenRegistry
+	perfect *perfectIndex
+}
+
+func newRegistryState[T any]() *registryState[T] {
+	return &registryState[T]{
+		prefixes:   make(map[string]string),
+		prefixers:  make(map[string]IDPrefixer[T]),
+		generators: make(map[string]Generator[T]),
+		aliases:    make(map[string][]string),
+		trie:       &prefixTrie{},
 	}
 }
 
-// NewRegistryWithPrefixes creates a new registry with predefined prefixes
-func NewRegistryWithPrefixes[T any](prefixMap map[string]string) *Registry[T] {
-	return &Registry[T]{
-		prefixes:  prefixMap,
-		prefixers: make(map[string]IDPrefixer[T]),
+// clone returns a copy of the state that can be modified before it is
+// published
+func (s *registryState[T]) clone() *registryState[T] {
+	return &registryState[T]{
+		prefixes:   maps.Clone(s.prefixes),
+		prefixers:  maps.Clone(s.prefixers),
+		generators: maps.Clone(s.generators),
+		aliases:    maps.Clone(s.aliases),
+		scopes:     s.scopes,
+		trie:       s.trie,
 	}
 }
 
-// Register adds or updates a prefix for an entity type
-func (r *Registry[T]) Register(entityType, prefix string, prefixer IDPrefixer[T]) {
+// update applies fn to a copy of the current state and publishes it unless
+// fn fails
+func (r *Registry[T]) update(fn func(s *registryState[T]) error) error {
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
-	r.prefixes[entityType] = prefix
-	r.prefixers[entityType] = prefixer
+
+	s := r.state.Load().clone()
+	if err := fn(s); err != nil {
+		return err
+	}
+
+	s.rebuildTrie()
+	r.state.Store(s)
+	return nil
+}
+
+// RegistryOption configures a Registry
+type RegistryOption func(*registryOptions)
+
+type registryOptions struct {
+	policy    PrefixPolicy
+	scopes    []string
+	scoping   ScopePolicy
+	aliasHook AliasHook
+}
+
+// WithPrefixPolicy sets the policy enforced by TryRegister, MustRegister,
+// RegisterAlias, Apply and Replace. The NewRegistryWith constructors don't
+// enforce it.
+func WithPrefixPolicy(policy PrefixPolicy) RegistryOption {
+	return func(o *registryOptions) {
+		o.policy = policy
+	}
+}
+
+// NewRegistry creates a new prefix registry
+func NewRegistry[T any](opts ...RegistryOption) *Registry[T] {
+	return newRegistry(newRegistryState[T](), opts)
+}
+
+func newRegistry[T any](s *registryState[T], opts []RegistryOption) *Registry[T] {
+	o := registryOptions{policy: DefaultPrefixPolicy}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	r := &Registry[T]{
+		policy:    o.policy,
+		scopes:    sortScopes(o.scopes),
+		scoping:   o.scoping,
+		aliasHook: o.aliasHook,
+		scopeErr:  validateScopes(o.scopes, o.policy),
+	}
+	s.scopes = r.scopes
+	s.rebuildTrie()
+	r.state.Store(s)
+	return r
+}
+
+// NewRegistryWithPrefixes creates a new registry with predefined prefixes.
+// The map is copied. No prefixers are registered, so each entity type must
+// still be registered before use; see NewRegistryWithPrefixer. Like the
+// other NewRegistryWith constructors, it doesn't check the prefixes against
+// the PrefixPolicy.
+func NewRegistryWithPrefixes[T any](prefixMap map[string]string, opts ...RegistryOption) *Registry[T] {
+	s := newRegistryState[T]()
+	for entityType, prefix := range prefixMap {
+		s.prefixes[entityType] = prefix
+	}
+	return newRegistry(s, opts)
+}
+
+// NewRegistryWithPrefixer creates a new registry with predefined prefixes,
+// all using the same prefixer. The map is copied. The prefixes aren't
+// checked against the PrefixPolicy set by WithPrefixPolicy; use
+// NewRegistryFromConfig or Replace to validate them.
+func NewRegistryWithPrefixer[T any](prefixMap map[string]string, prefixer IDPrefixer[T], opts ...RegistryOption) *Registry[T] {
+	s := newRegistryState[T]()
+	for entityType, prefix := range prefixMap {
+		s.prefixes[entityType] = prefix
+		s.prefixers[entityType] = prefixer
+	}
+	return newRegistry(s, opts)
+}
+
+// Entry is the registration of an entity type
+type Entry[T any] struct {
+	// Prefix for the entity type
+	Prefix string
+	// Prefixer for the entity type's IDs
+	Prefixer IDPrefixer[T]
+	// Generator for new IDs, optional
+	Generator Generator[T]
+	// Aliases accepted in addition to the prefix, optional
+	Aliases []string
+}
+
+// NewRegistryWithEntries creates a new registry with a prefix and prefixer
+// per entity type. The map is copied. The entries aren't checked against the
+// PrefixPolicy set by WithPrefixPolicy; to validate them, call Replace on a
+// new registry, or use NewRegistryFromConfig or a Builder.
+func NewRegistryWithEntries[T any](entries map[string]Entry[T], opts ...RegistryOption) *Registry[T] {
+	s := newRegistryState[T]()
+	for entityType, entry := range entries {
+		s.set(entityType, entry)
+	}
+	return newRegistry(s, opts)
+}
+
+// set registers an entity type, replacing any existing registration
+func (s *registryState[T]) set(entityType string, entry Entry[T]) {
+	s.remove(entityType)
+
+	s.prefixes[entityType] = entry.Prefix
+	if entry.Prefixer != nil {
+		s.prefixers[entityType] = entry.Prefixer
+	}
+	if entry.Generator != nil {
+		s.generators[entityType] = entry.Generator
+	}
+	if len(entry.Aliases) > 0 {
+		s.aliases[entityType] = slices.Clone(entry.Aliases)
+	}
+}
+
+// remove unregisters an entity type
+func (s *registryState[T]) remove(entityType string) {
+	delete(s.prefixes, entityType)
+	delete(s.prefixers, entityType)
+	delete(s.generators, entityType)
+	delete(s.aliases, entityType)
+}
+
+// Register adds or updates a prefix for an entity type without validation
+func (r *Registry[T]) Register(entityType, prefix string, prefixer IDPrefixer[T]) {
+	r.update(func(s *registryState[T]) error {
+		s.prefixes[entityType] = prefix
+		s.prefixers[entityType] = prefixer
+		return nil
+	})
+}
+
+// TryRegister adds a prefix for a new entity type. It fails if the entity
+// type is already registered, if the prefix doesn't satisfy the registry's
+// PrefixPolicy, or if the prefix duplicates or overlaps another entity
+// type's prefix. It also fails if one of the registry's scopes doesn't
+// satisfy the PrefixPolicy.
+func (r *Registry[T]) TryRegister(entityType, prefix string, prefixer IDPrefixer[T]) error {
+	if r.scopeErr != nil {
+		return r.scopeErr
+	}
+	return r.tryRegister(entityType, prefix, prefixer)
+}
+
+// tryRegister implements TryRegister without checking the scopes
+func (r *Registry[T]) tryRegister(entityType, prefix string, prefixer IDPrefixer[T]) error {
+	if prefixer == nil {
+		return fmt.Errorf("%w: %s", ErrNoPrefixer, entityType)
+	}
+
+	if err := r.policy.Validate(prefix); err != nil {
+		return fmt.Errorf("entity type %s: %w", entityType, err)
+	}
+
+	return r.update(func(s *registryState[T]) error {
+		if _, ok := s.prefixes[entityType]; ok {
+			return fmt.Errorf("%w: %s", ErrEntityTypeExists, entityType)
+		}
+
+		if err := r.policy.checkConflicts(entityType, prefix, s.allPrefixes()); err != nil {
+			return fmt.Errorf("entity type %s: %w", entityType, err)
+		}
+
+		s.prefixes[entityType] = prefix
+		s.prefixers[entityType] = prefixer
+		return nil
+	})
+}
+
+// MustRegister is like TryRegister but panics on error. It is intended for
+// setting up registries at init time.
+func (r *Registry[T]) MustRegister(entityType, prefix string, prefixer IDPrefixer[T]) {
+	if err := r.TryRegister(entityType, prefix, prefixer); err != nil {
+		panic(err)
+	}
+}
+
+// SetGenerator sets the generator used by New for a registered entity type
+func (r *Registry[T]) SetGenerator(entityType string, generator Generator[T]) error {
+	return r.update(func(s *registryState[T]) error {
+		if _, ok := s.prefixes[entityType]; !ok {
+			return fmt.Errorf("%w: %s", ErrUnknownEntityType, entityType)
+		}
+
+		if generator == nil {
+			delete(s.generators, entityType)
+		} else {
+			s.generators[entityType] = generator
+		}
+		return nil
+	})
+}
+
+// rebuildTrie reindexes the prefixes and aliases that have a prefixer
+func (s *registryState[T]) rebuildTrie() {
+	trie := &prefixTrie{}
+	for _, entry := range s.indexedPrefixes() {
+		trie.insert(entry)
+	}
+	s.trie = trie
+}
+
+// indexedPrefixes returns the prefixes and aliases of the entity types that
+// have a prefixer, unscoped and in each of the registry's scopes
+func (s *registryState[T]) indexedPrefixes() []trieEntry {
+	var entries []trieEntry
+	add := func(entityType, prefix, alias string) {
+		entries = append(entries, trieEntry{entityType: entityType, prefix: prefix, alias: alias})
+		for _, scope := range s.scopes {
+			entries = append(entries, trieEntry{entityType: entityType, prefix: ScopedPrefix(prefix, scope), alias: alias, scope: scope})
+		}
+	}
+
+	for entityType, prefixer := range s.prefixers {
+		prefix, ok := s.prefixes[entityType]
+		if prefixer == nil || !ok {
+			continue
+		}
+
+		add(entityType, prefix, "")
+		for _, alias := range s.aliases[entityType] {
+			add(entityType, alias, alias)
+		}
+	}
+	return entries
 }
 
 // GetEntityTypes returns all registered entity types
 func (r *Registry[T]) GetEntityTypes() []string {
-	r.mutex.RLock()
-	defer r.mutex.RUnlock()
+	s := r.state.Load()
 
-	types := make([]string, 0, len(r.prefixes))
-	for entityType := range r.prefixes {
+	types := make([]string, 0, len(s.prefixes))
+	for entityType := range s.prefixes {
 		types = append(types, entityType)
 	}
 	return types
 }
 
-// PrefixID creates a prefixed ID string for an entity type and ID
-func (r *Registry[T]) PrefixID(entityType string, id T) (string, error) {
-	r.mutex.RLock()
-	defer r.mutex.RUnlock()
-
-	prefix, ok := r.prefixes[entityType]
+// lookup returns the prefix and prefixer of an entity type
+func (s *registryState[T]) lookup(entityType string) (string, IDPrefixer[T], error) {
+	prefix, ok := s.prefixes[entityType]
 	if !ok {
-		return "", fmt.Errorf("no prefix registered for entity type: %s", entityType)
+		return "", nil, fmt.Errorf("%w: %s", ErrUnknownEntityType, entityType)
 	}
 
-	prefixer, ok := r.prefixers[entityType]
+	prefixer, ok := s.prefixers[entityType]
 	if !ok {
-		return "", fmt.Errorf("no prefixer registered for entity type: %s", entityType)
+		return "", nil, fmt.Errorf("%w: %s", ErrNoPrefixer, entityType)
 	}
+	return prefix, prefixer, nil
+}
 
+// PrefixID creates a prefixed ID string for an entity type and ID
+func (r *Registry[T]) PrefixID(entityType string, id T) (string, error) {
+	prefix, prefixer, err := r.state.Load().lookup(entityType)
+	if err != nil {
+		return "", err
+	}
 	return prefixer.Attach(prefix, id), nil
 }
 
-// ParsePrefixedID attempts to parse a prefixed ID string for a given entity type
-func (r *Registry[T]) ParsePrefixedID(entityType, prefixedID string) (T, error) {
-	r.mutex.RLock()
-	defer r.mutex.RUnlock()
+// AppendPrefixID appends the prefixed ID string for an entity type to dst.
+// It doesn't allocate if the prefixer implements Appender and dst has room.
+// A prefixer that embeds a built-in prefixer to override Attach must
+// override AppendPrefixed as well.
+func (r *Registry[T]) AppendPrefixID(dst []byte, entityType string, id T) ([]byte, error) {
+	prefix, prefixer, err := r.state.Load().lookup(entityType)
+	if err != nil {
+		return dst, err
+	}
+
+	if appender, ok := prefixer.(Appender[T]); ok {
+		return appender.AppendPrefixed(dst, prefix, id), nil
+	}
+	return append(dst, prefixer.Attach(prefix, id)...), nil
+}
 
+// New generates a new ID for an entity type and returns it along with its
+// prefixed form. The entity type's generator is used, or its prefixer if
+// that implements Generator[T].
+func (r *Registry[T]) New(entityType string) (string, T, error) {
 	var zero T
 
-	prefix, ok := r.prefixes[entityType]
-	if !ok {
-		return zero, fmt.Errorf("no prefix registered for entity type: %s", entityType)
+	s := r.state.Load()
+	prefix, prefixer, err := s.lookup(entityType)
+	if err != nil {
+		return "", zero, err
 	}
 
-	prefixer, ok := r.prefixers[entityType]
+	generator, ok := s.generators[entityType]
 	if !ok {
-		return zero, fmt.Errorf("no prefixer registered for entity type: %s", entityType)
+		if generator, ok = prefixer.(Generator[T]); !ok {
+			return "", zero, fmt.Errorf("%w: %s", ErrNoGenerator, entityType)
+		}
 	}
 
+	id, err := generator.Generate()
+	if err != nil {
+		return "", zero, fmt.Errorf("generate ID for entity type %s: %w", entityType, err)
+	}
+	return prefixer.Attach(prefix, id), id, nil
+}
+
+// ParsePrefixedID attempts to parse a prefixed ID string for a given entity type.
+// IDs with one of the entity type's aliases are accepted too.
+// Parse failures are reported as a *ParseError. IDs that only parse with
+// a scoped prefix, like "sk_live_<uuid>", are parsed like in ParseScopedID
+// and checked against the registry's ScopePolicy.
+func (r *Registry[T]) ParsePrefixedID(entityType, prefixedID string) (T, error) {
+	var zero T
+
+	s := r.state.Load()
+	prefix, prefixer, err := s.lookup(entityType)
+	if err != nil {
+		return zero, err
+	}
+
+	var alias string
 	rawStr, ok := prefixer.Detach(prefix, prefixedID)
-	if !ok {
-		return zero, fmt.Errorf("invalid prefix format for entity type: %s", entityType)
+	for i := 0; !ok && i < len(s.aliases[entityType]); i++ {
+		alias = s.aliases[entityType][i]
+		rawStr, ok = prefixer.Detach(alias, prefixedID)
+	}
+
+	var parseErr error
+	if ok {
+		id, err := prefixer.Parse(rawStr)
+		if err == nil {
+			r.reportAlias(entityType, alias, prefixedID)
+			return id, nil
+		}
+		parseErr = &ParseError{EntityType: entityType, Prefix: prefix, Input: prefixedID, Kind: ErrMalformedID, Err: err}
 	}
 
-	return prefixer.Parse(rawStr)
+	if _, id, ok, err := r.parseScoped(s, entityType, prefixedID); ok {
+		return id, err
+	}
+
+	if parseErr != nil {
+		return zero, parseErr
+	}
+	return zero, detachError(prefixer, entityType, prefix, prefixedID)
 }
 
-// MatchPrefix tries to determine the entity type from a prefixed ID
+// MatchPrefix tries to determine the entity type from a prefixed ID.
+//
+// When several prefixes match, the longest one wins, so with "us" and "usr"
+// registered "usr_123" resolves to the "usr" entity type. Entity types
+// sharing the same prefix are tried in lexical order. Aliases match like
+// prefixes. Scoped IDs are matched with their scoped prefix, so the raw ID
+// doesn't include the scope, and don't match if the registry's ScopePolicy
+// rejects their scope.
 func (r *Registry[T]) MatchPrefix(prefixedID string) (string, string, bool) {
-	r.mutex.RLock()
-	defer r.mutex.RUnlock()
+	s := r.state.Load()
+
+	entry, rawStr, ok := s.match(prefixedID)
+	if !ok || r.checkScope(entry.entityType, entry.scope) != nil {
+		return "", "", false
+	}
+
+	r.reportAlias(entry.entityType, entry.alias, prefixedID)
+	return entry.entityType, rawStr, true
+}
 
-	for entityType, prefix := range r.prefixes {
-		prefixer := r.prefixers[entityType]
-		if rawStr, ok := prefixer.Detach(prefix, prefixedID); ok {
-			return entityType, rawStr, true
+// match implements MatchPrefix, returning the matched entry. A scoped
+// prefix only matches IDs that don't parse with its unscoped prefix, so
+// an unscoped ID whose body starts with a scope, like "doc_test_report",
+// isn't mistaken for a scoped one.
+func (s *registryState[T]) match(prefixedID string) (*trieEntry, string, bool) {
+	var matched *trieEntry
+	var matchedRaw string
+	detach := func(entry *trieEntry) bool {
+		prefixer := s.prefixers[entry.entityType]
+		rawStr, ok := prefixer.Detach(entry.prefix, prefixedID)
+		if !ok || entry.scope != "" && parsesWith(prefixer, entry.base(), prefixedID) {
+			return false
 		}
+		matched, matchedRaw = entry, rawStr
+		return true
+	}
+
+	var ok bool
+	if s.perfect != nil {
+		ok = s.perfect.match(prefixedID, detach)
+	} else {
+		ok = s.trie.match(prefixedID, detach)
+	}
+
+	return matched, matchedRaw, ok
+}
+
+// Resolve determines the entity type of a prefixed ID, like MatchPrefix,
+// and parses the ID. It returns an error matching ErrUnknownPrefix if no
+// registered prefix matches, and a *ParseError matching ErrMalformedID if
+// the prefix matches but the ID cannot be parsed, or ErrPrefixMismatch if
+// the ID's scope is rejected by the registry's ScopePolicy.
+func (r *Registry[T]) Resolve(prefixedID string) (string, T, error) {
+	var zero T
+
+	s := r.state.Load()
+	entry, rawStr, ok := s.match(prefixedID)
+	if !ok {
+		return "", zero, fmt.Errorf("%w: %q", ErrUnknownPrefix, prefixedID)
+	}
+
+	entityType := entry.entityType
+	if err := r.checkScope(entityType, entry.scope); err != nil {
+		return entityType, zero, &ParseError{EntityType: entityType, Prefix: entry.prefix, Input: prefixedID, Kind: ErrPrefixMismatch, Err: err}
+	}
+
+	id, err := s.prefixers[entityType].Parse(rawStr)
+	if err != nil {
+		return entityType, zero, &ParseError{EntityType: entityType, Prefix: s.prefixes[entityType], Input: prefixedID, Kind: ErrMalformedID, Err: err}
 	}
 
-	return "", "", false
+	r.reportAlias(entityType, entry.alias, prefixedID)
+	return entityType, id, nil
 }
@@ -0,0 +1,178 @@
+package prefixid
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+)
+
+// ScopeSeparator joins a prefix and a scope
+const ScopeSeparator = "_"
+
+// ScopePolicy decides whether IDs of an entity type may use a scope,
+// returning an error if not
+type ScopePolicy func(entityType, scope string) error
+
+// AllowScopes returns a ScopePolicy that only accepts the given scopes, e.g.
+// AllowScopes("live") in production
+func AllowScopes(scopes ...string) ScopePolicy {
+	return func(entityType, scope string) error {
+		if !slices.Contains(scopes, scope) {
+			return fmt.Errorf("%w: %s: %q", ErrScopeNotAllowed, entityType, scope)
+		}
+		return nil
+	}
+}
+
+// WithScopes sets the scopes of scoped IDs, like "test" and "live", which
+// become part of the prefix: "sk" in scope "test" is "sk_test". Scopes must
+// satisfy the registry's PrefixPolicy; if one doesn't, TryRegister,
+// MustRegister, RegisterAlias, Apply and Replace fail with an error matching
+// ErrInvalidPrefix.
+func WithScopes(scopes ...string) RegistryOption {
+	return func(o *registryOptions) {
+		o.scopes = append(o.scopes, scopes...)
+	}
+}
+
+// WithScopePolicy sets the policy checked when scoped IDs are created and
+// parsed
+func WithScopePolicy(policy ScopePolicy) RegistryOption {
+	return func(o *registryOptions) {
+		o.scoping = policy
+	}
+}
+
+// ScopedPrefix returns the prefix of an entity type in a scope
+func ScopedPrefix(prefix, scope string) string {
+	return prefix + ScopeSeparator + scope
+}
+
+// sortScopes returns the distinct scopes, longest first so that "test_eu"
+// is tried before "test"
+func sortScopes(scopes []string) []string {
+	sorted := slices.Clone(scopes)
+	sort.Slice(sorted, func(i, j int) bool {
+		if len(sorted[i]) != len(sorted[j]) {
+			return len(sorted[i]) > len(sorted[j])
+		}
+		return sorted[i] < sorted[j]
+	})
+	return slices.Compact(sorted)
+}
+
+// validateScopes checks scopes against a policy, returning every problem
+// found
+func validateScopes(scopes []string, policy PrefixPolicy) error {
+	var errs []error
+	for _, scope := range scopes {
+		if err := policy.Validate(scope); err != nil {
+			errs = append(errs, fmt.Errorf("scope %q: %w", scope, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Scopes returns the registry's scopes
+func (r *Registry[T]) Scopes() []string {
+	return slices.Clone(r.scopes)
+}
+
+// PrefixScopedID creates a prefixed ID string for an entity type in a scope,
+// e.g. "sk_test_123". The scope must be one of the registry's scopes and
+// allowed by its ScopePolicy.
+func (r *Registry[T]) PrefixScopedID(entityType, scope string, id T) (string, error) {
+	prefix, prefixer, err := r.state.Load().lookup(entityType)
+	if err != nil {
+		return "", err
+	}
+
+	if !slices.Contains(r.scopes, scope) {
+		return "", fmt.Errorf("%w: %q", ErrUnknownScope, scope)
+	}
+
+	if r.scoping != nil {
+		if err := r.scoping(entityType, scope); err != nil {
+			return "", err
+		}
+	}
+
+	return prefixer.Attach(ScopedPrefix(prefix, scope), id), nil
+}
+
+// ParseScopedID parses a scoped ID string for a given entity type,
+// returning its scope. IDs with one of the entity type's aliases are
+// accepted too. IDs from a scope the ScopePolicy rejects fail with a
+// *ParseError matching ErrPrefixMismatch and the policy's error.
+func (r *Registry[T]) ParseScopedID(entityType, prefixedID string) (string, T, error) {
+	var zero T
+
+	s := r.state.Load()
+	prefix, prefixer, err := s.lookup(entityType)
+	if err != nil {
+		return "", zero, err
+	}
+
+	scope, id, ok, err := r.parseScoped(s, entityType, prefixedID)
+	if !ok {
+		return "", zero, detachError(prefixer, entityType, prefix, prefixedID)
+	}
+	return scope, id, err
+}
+
+// parseScoped parses an ID of an entity type with a scoped prefix, trying
+// its prefix and then its aliases in every scope. It returns false if no
+// scoped prefix matches.
+func (r *Registry[T]) parseScoped(s *registryState[T], entityType, prefixedID string) (string, T, bool, error) {
+	var zero T
+
+	prefixer := s.prefixers[entityType]
+	aliases := s.aliases[entityType]
+	for i := -1; i < len(aliases); i++ {
+		base, alias := s.prefixes[entityType], ""
+		if i >= 0 {
+			base, alias = aliases[i], aliases[i]
+		}
+
+		for _, scope := range r.scopes {
+			scopedPrefix := ScopedPrefix(base, scope)
+			rawStr, ok := prefixer.Detach(scopedPrefix, prefixedID)
+			if !ok {
+				continue
+			}
+
+			if err := r.checkScope(entityType, scope); err != nil {
+				return scope, zero, true, &ParseError{EntityType: entityType, Prefix: scopedPrefix, Input: prefixedID, Kind: ErrPrefixMismatch, Err: err}
+			}
+
+			id, err := prefixer.Parse(rawStr)
+			if err != nil {
+				return scope, zero, true, &ParseError{EntityType: entityType, Prefix: scopedPrefix, Input: prefixedID, Kind: ErrMalformedID, Err: err}
+			}
+
+			r.reportAlias(entityType, alias, prefixedID)
+			return scope, id, true, nil
+		}
+	}
+	return "", zero, false, nil
+}
+
+// checkScope applies the ScopePolicy to an ID in a scope, if any
+func (r *Registry[T]) checkScope(entityType, scope string) error {
+	if scope == "" || r.scoping == nil {
+		return nil
+	}
+	return r.scoping(entityType, scope)
+}
+
+// parsesWith reports whether prefixedID is a valid ID with prefix
+func parsesWith[T any](prefixer IDPrefixer[T], prefix, prefixedID string) bool {
+	rawStr, ok := prefixer.Detach(prefix, prefixedID)
+	if !ok {
+		return false
+	}
+
+	_, err := prefixer.Parse(rawStr)
+	return err == nil
+}
@@ -0,0 +1,132 @@
+package prefixid
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"fmt"
+)
+
+const (
+	// DefaultTagSize is the number of HMAC bytes SignedPrefixer keeps when
+	// TagSize is unset
+	DefaultTagSize = 12
+	// MinTagSize is the smallest TagSize SignedPrefixer uses
+	MinTagSize = 8
+)
+
+// SignedPrefixer wraps an IDPrefixer, appending a truncated HMAC-SHA256 of
+// the prefixed ID so IDs cannot be forged, even when the underlying IDs are
+// guessable. The signature is the key ID followed by the tag in base62, so
+// with key ID "k" and the default TagSize "usr_42" is signed as "usr_42k"
+// and 17 more characters.
+//
+// Detach only succeeds for IDs with a valid signature, which it strips.
+// Registry.ParsePrefixedID and the other parse functions report invalid
+// signatures as errors matching ErrInvalidSignature. Parse doesn't verify
+// anything, it only parses the underlying ID.
+type SignedPrefixer[T any] struct {
+	// Prefixer formats and parses the ID
+	Prefixer IDPrefixer[T]
+	// Keyring holds the signing keys
+	Keyring *Keyring
+	// TagSize is the number of HMAC bytes kept, DefaultTagSize if unset.
+	// Values outside MinTagSize to sha256.Size are clamped.
+	TagSize int
+}
+
+var (
+	_ IDPrefixer[int] = SignedPrefixer[int]{}
+	_ Verifier        = SignedPrefixer[int]{}
+)
+
+// NewSignedPrefixer wraps a prefixer with signatures using the keys in
+// keyring
+func NewSignedPrefixer[T any](prefixer IDPrefixer[T], keyring *Keyring) SignedPrefixer[T] {
+	return SignedPrefixer[T]{Prefixer: prefixer, Keyring: keyring}
+}
+
+// Attach attaches a prefix to an ID and signs it with the current key
+func (p SignedPrefixer[T]) Attach(prefix string, id T) string {
+	body := p.Prefixer.Attach(prefix, id)
+	keyID, key := p.Keyring.currentKey()
+	return body + p.signature(keyID, key, body)
+}
+
+// Detach verifies the signature of a prefixed ID and detaches the prefix
+func (p SignedPrefixer[T]) Detach(prefix string, prefixedID string) (string, bool) {
+	body, ok := p.verify(prefixedID)
+	if !ok {
+		return "", false
+	}
+	return p.Prefixer.Detach(prefix, body)
+}
+
+// Parse parses a string into an ID
+func (p SignedPrefixer[T]) Parse(s string) (T, error) {
+	return p.Prefixer.Parse(s)
+}
+
+// Verify returns an error matching ErrInvalidSignature if a prefixed ID
+// with the prefix has a signature that is missing, wrong or made with a key
+// not in the keyring. IDs with another prefix aren't verified.
+func (p SignedPrefixer[T]) Verify(prefix string, prefixedID string) error {
+	body := prefixedID
+	if n := 1 + base62Codec.encodedLen(p.tagSize()); len(prefixedID) > n {
+		body = prefixedID[:len(prefixedID)-n]
+	}
+	if _, ok := p.Prefixer.Detach(prefix, body); !ok {
+		return nil
+	}
+
+	if _, ok := p.verify(prefixedID); !ok {
+		return fmt.Errorf("%w: %q", ErrInvalidSignature, prefixedID)
+	}
+	return nil
+}
+
+// verify checks the signature at the end of a prefixed ID, returning the
+// rest of it
+func (p SignedPrefixer[T]) verify(prefixedID string) (string, bool) {
+	n := 1 + base62Codec.encodedLen(p.tagSize())
+	if len(prefixedID) <= n {
+		return "", false
+	}
+
+	body, signature := prefixedID[:len(prefixedID)-n], prefixedID[len(prefixedID)-n:]
+	key, ok := p.Keyring.key(signature[0])
+	if !ok {
+		return "", false
+	}
+
+	expected := p.signature(signature[0], key, body)
+	if subtle.ConstantTimeCompare([]byte(expected), []byte(signature)) != 1 {
+		return "", false
+	}
+	return body, true
+}
+
+// signature returns the key ID and tag for a prefixed ID
+func (p SignedPrefixer[T]) signature(keyID byte, key []byte, body string) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte{keyID})
+	mac.Write([]byte(body))
+
+	var buf [64]byte
+	dst := append(buf[:0], keyID)
+	return string(base62Codec.appendEncode(dst, mac.Sum(nil)[:p.tagSize()]))
+}
+
+// tagSize returns TagSize with the default applied and clamped
+func (p SignedPrefixer[T]) tagSize() int {
+	switch {
+	case p.TagSize == 0:
+		return DefaultTagSize
+	case p.TagSize < MinTagSize:
+		return MinTagSize
+	case p.TagSize > sha256.Size:
+		return sha256.Size
+	default:
+		return p.TagSize
+	}
+}
@@ -0,0 +1,318 @@
+package prefixid
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DefaultSqidsAlphabet is the alphabet SqidsPrefixer uses by default
+	DefaultSqidsAlphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	// MaxSqidsMinLength is the largest MinLength a SqidsConfig may have
+	MaxSqidsMinLength = 255
+
+	minSqidsAlphabetLength = 3
+)
+
+// DefaultSqidsBlocklist is the blocklist SqidsPrefixer uses when the config
+// doesn't set one. It is a short list of common English words; pass a more
+// complete list, such as the one published by the Sqids project, for
+// user-facing IDs.
+var DefaultSqidsBlocklist = []string{
+	"anal", "anus", "arse", "ass", "bastard", "bitch", "boob", "butt",
+	"cock", "crap", "cunt", "damn", "dick", "dildo", "fag", "fuck",
+	"hell", "jizz", "kike", "nazi", "nigg", "penis", "piss", "porn",
+	"pussy", "rape", "sex", "shit", "slut", "spic", "tits", "twat",
+	"vagina", "whore",
+}
+
+// SqidsConfig configures a SqidsPrefixer
+type SqidsConfig struct {
+	// Alphabet of the encoded IDs, DefaultSqidsAlphabet if empty. It needs
+	// at least 3 unique ASCII characters.
+	Alphabet string
+	// Salt reorders the alphabet, so entity types sharing an alphabet get
+	// unrelated IDs
+	Salt string
+	// MinLength pads encoded IDs to at least this many characters
+	MinLength int
+	// Blocklist of words encoded IDs must not contain, DefaultSqidsBlocklist
+	// if nil. Words are matched case-insensitively.
+	Blocklist []string
+}
+
+// SqidsPrefixer implements IDPrefixer for integer IDs, encoding them with
+// the Sqids algorithm so sequential IDs don't reveal counts, e.g. "cust_Jg"
+// instead of "cust_42". Without a salt the encoding is the same as other
+// Sqids implementations with the same alphabet, minimum length and
+// blocklist.
+//
+// Sqids is obfuscation, not encryption: anyone who knows the alphabet and
+// salt can decode IDs. Negative IDs are encoded as their 64-bit two's
+// complement. The zero value uses the default config.
+type SqidsPrefixer[T Integer] struct {
+	// Format controls how the prefix and ID are joined
+	Format Format
+
+	sqids *sqids
+}
+
+var _ IDPrefixer[int64] = SqidsPrefixer[int64]{}
+
+var defaultSqids = mustNewSqids(SqidsConfig{})
+
+// NewSqidsPrefixer creates a SqidsPrefixer with the given config and format
+// options
+func NewSqidsPrefixer[T Integer](config SqidsConfig, opts ...FormatOption) (SqidsPrefixer[T], error) {
+	s, err := newSqids(config)
+	if err != nil {
+		return SqidsPrefixer[T]{}, err
+	}
+	return SqidsPrefixer[T]{Format: NewFormat(opts...), sqids: s}, nil
+}
+
+// Attach attaches a prefix to an integer ID
+func (p SqidsPrefixer[T]) Attach(prefix string, id T) string {
+	return p.Format.Attach(prefix, p.encoder().encode(uint64(id)))
+}
+
+// Detach detaches a prefix from a prefixed ID string
+func (p SqidsPrefixer[T]) Detach(prefix string, prefixedID string) (string, bool) {
+	return p.Format.Detach(prefix, prefixedID)
+}
+
+// Parse decodes a Sqid into an integer ID. Only the Sqid Attach produces
+// for the ID is accepted. Errors are *strconv.NumError values wrapping
+// strconv.ErrSyntax or strconv.ErrRange.
+func (p SqidsPrefixer[T]) Parse(s string) (T, error) {
+	const fn = "ParseSqid"
+	sq := p.encoder()
+
+	n, ok := sq.decode(s)
+	if !ok || sq.encode(n) != s {
+		return 0, &strconv.NumError{Func: fn, Num: s, Err: strconv.ErrSyntax}
+	}
+
+	id := T(n)
+	signed, _ := integerInfo[T]()
+	if (signed && int64(id) != int64(n)) || (!signed && uint64(id) != n) {
+		return 0, &strconv.NumError{Func: fn, Num: s, Err: strconv.ErrRange}
+	}
+	return id, nil
+}
+
+func (p SqidsPrefixer[T]) encoder() *sqids {
+	if p.sqids == nil {
+		return defaultSqids
+	}
+	return p.sqids
+}
+
+// sqids encodes single integers following the Sqids specification
+type sqids struct {
+	alphabet  []byte
+	minLength int
+	blocklist []string
+}
+
+func mustNewSqids(config SqidsConfig) *sqids {
+	s, err := newSqids(config)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+func newSqids(config SqidsConfig) (*sqids, error) {
+	alphabet := config.Alphabet
+	if alphabet == "" {
+		alphabet = DefaultSqidsAlphabet
+	}
+
+	if len(alphabet) < minSqidsAlphabetLength {
+		return nil, fmt.Errorf("%w: alphabet needs at least %d characters", ErrInvalidSqidsConfig, minSqidsAlphabetLength)
+	}
+
+	var seen [256]bool
+	for i := 0; i < len(alphabet); i++ {
+		c := alphabet[i]
+		if c >= 0x80 {
+			return nil, fmt.Errorf("%w: alphabet %q is not ASCII", ErrInvalidSqidsConfig, alphabet)
+		}
+		if seen[c] {
+			return nil, fmt.Errorf("%w: alphabet repeats %q", ErrInvalidSqidsConfig, c)
+		}
+		seen[c] = true
+	}
+
+	if config.MinLength < 0 || config.MinLength > MaxSqidsMinLength {
+		return nil, fmt.Errorf("%w: minimum length must be between 0 and %d", ErrInvalidSqidsConfig, MaxSqidsMinLength)
+	}
+
+	blocklist := config.Blocklist
+	if blocklist == nil {
+		blocklist = DefaultSqidsBlocklist
+	}
+
+	// Only words that could appear in an ID need checking
+	lowerAlphabet := strings.ToLower(alphabet)
+	var words []string
+	for _, word := range blocklist {
+		word = strings.ToLower(word)
+		if len(word) >= 3 && strings.Trim(word, lowerAlphabet) == "" {
+			words = append(words, word)
+		}
+	}
+
+	chars := []byte(alphabet)
+	saltShuffle(chars, config.Salt)
+	sqidsShuffle(chars)
+
+	return &sqids{alphabet: chars, minLength: config.MinLength, blocklist: words}, nil
+}
+
+// encode encodes n, trying the next alphabet offset while the result
+// contains a blocked word
+func (s *sqids) encode(n uint64) string {
+	var id string
+	for increment := 0; increment <= len(s.alphabet); increment++ {
+		id = s.encodeOffset(n, increment)
+		if !s.isBlocked(id) {
+			return id
+		}
+	}
+	// Every offset is blocked, which only a pathological blocklist causes
+	return id
+}
+
+func (s *sqids) encodeOffset(n uint64, increment int) string {
+	size := len(s.alphabet)
+	offset := (1 + int(s.alphabet[n%uint64(size)]) + increment) % size
+
+	alphabet := make([]byte, size)
+	copy(alphabet, s.alphabet[offset:])
+	copy(alphabet[size-offset:], s.alphabet[:offset])
+
+	prefix := alphabet[0]
+	reverseBytes(alphabet)
+
+	id := []byte{prefix}
+	id = appendSqidsNumber(id, n, alphabet[1:])
+
+	if len(id) < s.minLength {
+		id = append(id, alphabet[0])
+		for len(id) < s.minLength {
+			sqidsShuffle(alphabet)
+			id = append(id, alphabet[:min(s.minLength-len(id), size)]...)
+		}
+	}
+	return string(id)
+}
+
+// decode decodes an ID holding a single number
+func (s *sqids) decode(id string) (uint64, bool) {
+	if id == "" {
+		return 0, false
+	}
+
+	offset := strings.IndexByte(string(s.alphabet), id[0])
+	if offset < 0 {
+		return 0, false
+	}
+
+	size := len(s.alphabet)
+	alphabet := make([]byte, size)
+	copy(alphabet, s.alphabet[offset:])
+	copy(alphabet[size-offset:], s.alphabet[:offset])
+	reverseBytes(alphabet)
+
+	// The number ends at the first separator; anything after it is padding
+	// or further numbers, which encode rejects by producing a different ID
+	chunk, _, _ := strings.Cut(id[1:], string(alphabet[0]))
+	if chunk == "" {
+		return 0, false
+	}
+	return parseSqidsNumber(chunk, alphabet[1:])
+}
+
+// isBlocked reports whether an ID contains a blocked word
+func (s *sqids) isBlocked(id string) bool {
+	id = strings.ToLower(id)
+	for _, word := range s.blocklist {
+		switch {
+		case len(word) > len(id):
+		case len(id) <= 3 || len(word) <= 3:
+			if id == word {
+				return true
+			}
+		case strings.ContainsAny(word, "0123456789"):
+			if strings.HasPrefix(id, word) || strings.HasSuffix(id, word) {
+				return true
+			}
+		case strings.Contains(id, word):
+			return true
+		}
+	}
+	return false
+}
+
+// appendSqidsNumber appends n in the digits of alphabet, most significant
+// first
+func appendSqidsNumber(dst []byte, n uint64, alphabet []byte) []byte {
+	var buf [64]byte
+	base := uint64(len(alphabet))
+
+	i := len(buf)
+	for {
+		i--
+		buf[i] = alphabet[n%base]
+		n /= base
+		if n == 0 {
+			break
+		}
+	}
+	return append(dst, buf[i:]...)
+}
+
+// parseSqidsNumber parses a number in the digits of alphabet
+func parseSqidsNumber(s string, alphabet []byte) (uint64, bool) {
+	base := uint64(len(alphabet))
+	var n uint64
+	for i := 0; i < len(s); i++ {
+		digit := strings.IndexByte(string(alphabet), s[i])
+		if digit < 0 || n > (^uint64(0)-uint64(digit))/base {
+			return 0, false
+		}
+		n = n*base + uint64(digit)
+	}
+	return n, true
+}
+
+// sqidsShuffle is the deterministic shuffle from the Sqids specification
+func sqidsShuffle(chars []byte) {
+	for i, j := 0, len(chars)-1; j > 0; i, j = i+1, j-1 {
+		r := (i*j + int(chars[i]) + int(chars[j])) % len(chars)
+		chars[i], chars[r] = chars[r], chars[i]
+	}
+}
+
+// saltShuffle reorders chars with a salt, like Hashids
+func saltShuffle(chars []byte, salt string) {
+	if salt == "" {
+		return
+	}
+
+	for i, v, p := len(chars)-1, 0, 0; i > 0; i, v = i-1, v+1 {
+		v %= len(salt)
+		p += int(salt[v])
+		j := (int(salt[v]) + v + p) % i
+		chars[i], chars[j] = chars[j], chars[i]
+	}
+}
+
+func reverseBytes(chars []byte) {
+	for i, j := 0, len(chars)-1; i < j; i, j = i+1, j-1 {
+		chars[i], chars[j] = chars[j], chars[i]
+	}
+}
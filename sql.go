@@ -0,0 +1,170 @@
+package prefixid
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+)
+
+// StorageMode controls how an ID is stored in a database column
+type StorageMode int
+
+const (
+	// StoragePrefixed stores the full prefixed ID string, e.g. "ord_6ba7b810-..."
+	StoragePrefixed StorageMode = iota
+	// StorageRaw stores the underlying ID in its native database form: the
+	// ID's own driver.Valuer if it has one (a UUID string, ULID bytes or
+	// KSUID string), int64 for integers and the string for strings. The
+	// prefix is attached again on scan.
+	StorageRaw
+	// StorageBinary stores the underlying ID as bytes if it implements
+	// encoding.BinaryMarshaler (16 UUID bytes, 16 ULID bytes or 20 KSUID
+	// bytes), and as StorageRaw otherwise.
+	StorageBinary
+)
+
+// EntityStorage can be implemented by an Entity to choose how its IDs are
+// stored in a database. Entities that don't implement it use StoragePrefixed.
+type EntityStorage interface {
+	// Storage returns the storage mode for the entity's IDs
+	Storage() StorageMode
+}
+
+// Value implements driver.Valuer
+func (i ID[E, T]) Value() (driver.Value, error) {
+	switch entityStorage[E]() {
+	case StorageRaw:
+		return rawValue(i.id)
+	case StorageBinary:
+		if m, ok := any(i.id).(encoding.BinaryMarshaler); ok {
+			return m.MarshalBinary()
+		}
+		return rawValue(i.id)
+	default:
+		text, err := i.MarshalText()
+		if err != nil {
+			return nil, err
+		}
+		return string(text), nil
+	}
+}
+
+// Scan implements sql.Scanner. A NULL resets the ID to its zero value.
+func (i *ID[E, T]) Scan(src any) error {
+	if src == nil {
+		*i = ID[E, T]{}
+		return nil
+	}
+
+	if entityStorage[E]() == StoragePrefixed {
+		switch v := src.(type) {
+		case string:
+			return i.UnmarshalText([]byte(v))
+		case []byte:
+			return i.UnmarshalText(v)
+		default:
+			return fmt.Errorf("cannot scan %T into prefixed ID", src)
+		}
+	}
+
+	var id T
+	if err := scanRaw(&id, src); err != nil {
+		return err
+	}
+	i.id = id
+	return nil
+}
+
+// entityStorage returns the storage mode for IDs of entity E
+func entityStorage[E Entity]() StorageMode {
+	var e E
+	if es, ok := any(e).(EntityStorage); ok {
+		return es.Storage()
+	}
+	return StoragePrefixed
+}
+
+// rawValue converts an underlying ID to a driver.Value
+func rawValue(id any) (driver.Value, error) {
+	if v, ok := id.(driver.Valuer); ok {
+		return v.Value()
+	}
+
+	rv := reflect.ValueOf(id)
+	switch rv.Kind() {
+	case reflect.String:
+		return rv.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if rv.Uint() > math.MaxInt64 {
+			return nil, fmt.Errorf("ID %d overflows int64", rv.Uint())
+		}
+		return int64(rv.Uint()), nil
+	}
+
+	if m, ok := id.(encoding.BinaryMarshaler); ok {
+		return m.MarshalBinary()
+	}
+	return nil, fmt.Errorf("cannot store ID of type %T", id)
+}
+
+// scanRaw scans a driver value into an underlying ID
+func scanRaw(dest any, src any) error {
+	if s, ok := dest.(sql.Scanner); ok {
+		return s.Scan(src)
+	}
+
+	if b, ok := src.([]byte); ok {
+		if u, ok := dest.(encoding.BinaryUnmarshaler); ok {
+			return u.UnmarshalBinary(b)
+		}
+		src = string(b)
+	}
+
+	rv := reflect.ValueOf(dest).Elem()
+	switch rv.Kind() {
+	case reflect.String:
+		if s, ok := src.(string); ok {
+			rv.SetString(s)
+			return nil
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := scanInt64(src)
+		if err != nil {
+			return err
+		}
+		if rv.OverflowInt(n) {
+			return fmt.Errorf("value %d overflows %s", n, rv.Type())
+		}
+		rv.SetInt(n)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := scanInt64(src)
+		if err != nil {
+			return err
+		}
+		if n < 0 || rv.OverflowUint(uint64(n)) {
+			return fmt.Errorf("value %d overflows %s", n, rv.Type())
+		}
+		rv.SetUint(uint64(n))
+		return nil
+	}
+	return fmt.Errorf("cannot scan %T into ID of type %s", src, rv.Type())
+}
+
+// scanInt64 converts an integer driver value to int64
+func scanInt64(src any) (int64, error) {
+	switch v := src.(type) {
+	case int64:
+		return v, nil
+	case string:
+		return strconv.ParseInt(v, 10, 64)
+	default:
+		return 0, fmt.Errorf("cannot scan %T into integer ID", src)
+	}
+}
@@ -1,27 +1,34 @@
 package prefixid
 
-import (
-	"fmt"
-	"strings"
-)
-
 // StringPrefixer implements IDPrefixer for string IDs
-type StringPrefixer struct{}
+type StringPrefixer struct {
+	// Format controls how the prefix and ID are joined
+	Format Format
+}
+
+var (
+	_ IDPrefixer[string] = StringPrefixer{}
+	_ Appender[string]   = StringPrefixer{}
+)
 
-var _ IDPrefixer[string] = StringPrefixer{}
+// NewStringPrefixer creates a StringPrefixer with the given format options
+func NewStringPrefixer(opts ...FormatOption) StringPrefixer {
+	return StringPrefixer{Format: NewFormat(opts...)}
+}
 
 // Attach attaches a prefix to a string ID
 func (p StringPrefixer) Attach(prefix string, id string) string {
-	return fmt.Sprintf("%s_%s", prefix, id)
+	return p.Format.Attach(prefix, id)
+}
+
+// AppendPrefixed appends a prefixed string ID to dst
+func (p StringPrefixer) AppendPrefixed(dst []byte, prefix string, id string) []byte {
+	return p.Format.AppendPrefixed(dst, prefix, id)
 }
 
 // Detach detaches a prefix from a prefixed ID string
 func (p StringPrefixer) Detach(prefix string, prefixedID string) (string, bool) {
-	expectedPrefix := fmt.Sprintf("%s_", prefix)
-	if strings.HasPrefix(prefixedID, expectedPrefix) {
-		return strings.TrimPrefix(prefixedID, expectedPrefix), true
-	}
-	return "", false
+	return p.Format.Detach(prefix, prefixedID)
 }
 
 // Parse parses a string into a string ID (no-op for strings)
@@ -0,0 +1,104 @@
+package prefixid_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jasonKoogler/prefixid"
+)
+
+type aliasUse struct {
+	entityType, alias, prefixedID string
+}
+
+func newAliasRegistry(t *testing.T) (*prefixid.Registry[string], *[]aliasUse) {
+	t.Helper()
+
+	var uses []aliasUse
+	registry := prefixid.NewRegistry[string](prefixid.WithAliasHook(func(entityType, alias, prefixedID string) {
+		uses = append(uses, aliasUse{entityType, alias, prefixedID})
+	}))
+	registry.MustRegister("product", "prd", prefixid.StringPrefixer{})
+	registry.MustRegister("user", "usr", prefixid.StringPrefixer{})
+
+	if err := registry.RegisterAlias("product", "prod"); err != nil {
+		t.Fatalf("Failed to register alias: %v", err)
+	}
+	return registry, &uses
+}
+
+func TestRegistry_Aliases(t *testing.T) {
+	registry, uses := newAliasRegistry(t)
+
+	prefixedID, err := registry.PrefixID("product", "abc")
+	if err != nil || prefixedID != "prd_abc" {
+		t.Errorf("Expected prd_abc, got %s (err=%v)", prefixedID, err)
+	}
+
+	for _, input := range []string{"prd_abc", "prod_abc"} {
+		id, err := registry.ParsePrefixedID("product", input)
+		if err != nil || id != "abc" {
+			t.Errorf("Expected abc from %s, got %s (err=%v)", input, id, err)
+		}
+
+		entityType, rawID, ok := registry.MatchPrefix(input)
+		if !ok || entityType != "product" || rawID != "abc" {
+			t.Errorf("Expected product abc from %s, got %s %s (ok=%v)", input, entityType, rawID, ok)
+		}
+
+		entityType, id, err = registry.Resolve(input)
+		if err != nil || entityType != "product" || id != "abc" {
+			t.Errorf("Expected product abc from %s, got %s %s (err=%v)", input, entityType, id, err)
+		}
+	}
+
+	expected := aliasUse{"product", "prod", "prod_abc"}
+	if len(*uses) != 3 {
+		t.Fatalf("Expected 3 alias uses, got %v", *uses)
+	}
+
+	for _, use := range *uses {
+		if use != expected {
+			t.Errorf("Expected %v, got %v", expected, use)
+		}
+	}
+
+	if _, err := registry.ParsePrefixedID("user", "prod_abc"); !errors.Is(err, prefixid.ErrPrefixMismatch) {
+		t.Errorf("Expected ErrPrefixMismatch for another entity type's alias, got %v", err)
+	}
+
+	if aliases := registry.Aliases("product"); len(aliases) != 1 || aliases[0] != "prod" {
+		t.Errorf("Expected [prod], got %v", aliases)
+	}
+}
+
+func TestRegistry_RegisterAliasErrors(t *testing.T) {
+	registry, _ := newAliasRegistry(t)
+
+	testCases := []struct {
+		name        string
+		entityType  string
+		alias       string
+		expectedErr error
+	}{
+		{"unknown entity type", "order", "ord", prefixid.ErrUnknownEntityType},
+		{"invalid alias", "product", "Prod!", prefixid.ErrInvalidPrefix},
+		{"own prefix", "product", "prd", prefixid.ErrDuplicatePrefix},
+		{"existing alias", "product", "prod", prefixid.ErrDuplicatePrefix},
+		{"other prefix", "product", "usr", prefixid.ErrDuplicatePrefix},
+		{"overlapping prefix", "product", "us", prefixid.ErrPrefixOverlap},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := registry.RegisterAlias(tc.entityType, tc.alias); !errors.Is(err, tc.expectedErr) {
+				t.Errorf("Expected %v, got %v", tc.expectedErr, err)
+			}
+		})
+	}
+
+	// New prefixes can't take an alias either
+	if err := registry.TryRegister("promotion", "prod", prefixid.StringPrefixer{}); !errors.Is(err, prefixid.ErrDuplicatePrefix) {
+		t.Errorf("Expected ErrDuplicatePrefix, got %v", err)
+	}
+}
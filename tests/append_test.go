@@ -0,0 +1,150 @@
+package prefixid_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jasonKoogler/prefixid"
+	"github.com/oklog/ulid/v2"
+	"github.com/segmentio/ksuid"
+)
+
+var (
+	appendUUID  = uuid.MustParse("f47ac10b-58cc-0372-8567-0e02b2c3d479")
+	appendULID  = ulid.MustParse("01ARZ3NDEKTSV4RRFFQ69G5FAV")
+	appendKSUID = ksuid.New()
+)
+
+// checkAppend checks that AppendPrefixed appends what Attach returns
+func checkAppend[T any](t *testing.T, prefixer prefixid.IDPrefixer[T], id T) {
+	t.Helper()
+
+	appender, ok := prefixer.(prefixid.Appender[T])
+	if !ok {
+		t.Fatalf("%T does not implement Appender", prefixer)
+	}
+
+	for _, prefix := range []string{"usr", "Usr", "ÜSR"} {
+		expected := prefixer.Attach(prefix, id)
+		result := appender.AppendPrefixed([]byte("head:"), prefix, id)
+		if string(result) != "head:"+expected {
+			t.Errorf("Expected head:%s, got %s", expected, result)
+		}
+	}
+}
+
+func TestAppendPrefixed(t *testing.T) {
+	formats := []struct {
+		name   string
+		format prefixid.Format
+	}{
+		{"zero value", prefixid.Format{}},
+		{"lower", prefixid.NewFormat(prefixid.WithPrefixCase(prefixid.CaseLower))},
+		{"upper", prefixid.NewFormat(prefixid.WithPrefixCase(prefixid.CaseUpper))},
+		{"all", prefixid.NewFormat(
+			prefixid.WithSeparator("::"),
+			prefixid.WithPrefixCase(prefixid.CaseUpper),
+			prefixid.WithSuffix("@v1"),
+		)},
+	}
+
+	for _, f := range formats {
+		t.Run(f.name, func(t *testing.T) {
+			checkAppend[string](t, prefixid.StringPrefixer{Format: f.format}, "abc")
+			checkAppend[int](t, prefixid.IntPrefixer{Format: f.format}, -42)
+			checkAppend[uint64](t, prefixid.IntegerPrefixer[uint64]{Format: f.format, Encoding: prefixid.IntegerBase62}, 1<<63)
+			checkAppend[uuid.UUID](t, prefixid.UUIDPrefixer{Format: f.format}, appendUUID)
+			checkAppend[uuid.UUID](t, prefixid.UUIDPrefixer{Format: f.format, Encoding: prefixid.UUIDBase58}, appendUUID)
+			checkAppend[ulid.ULID](t, prefixid.ULIDPrefixer{Format: f.format}, appendULID)
+			checkAppend[ksuid.KSUID](t, prefixid.KSUIDPrefixer{Format: f.format}, appendKSUID)
+		})
+	}
+}
+
+func TestFormat_DetachSeparatorCase(t *testing.T) {
+	format := prefixid.NewFormat(prefixid.WithSeparator("x"), prefixid.WithPrefixCase(prefixid.CaseLower))
+
+	rawID, ok := format.Detach("usr", "USRX123")
+	if !ok || rawID != "123" {
+		t.Errorf("Expected to detach '123', got %q (ok=%v)", rawID, ok)
+	}
+}
+
+func TestRegistry_AppendPrefixID(t *testing.T) {
+	registry := prefixid.NewRegistry[int]()
+	registry.Register("user", "usr", prefixid.IntPrefixer{})
+	checksummed := prefixid.NewChecksumPrefixer[int](prefixid.IntPrefixer{}, prefixid.ChecksumMod37)
+	registry.Register("order", "ord", checksummed)
+
+	testCases := []struct {
+		name       string
+		entityType string
+		expected   string
+		err        error
+	}{
+		{"appender", "user", "ids=usr_42", nil},
+		{"falls back to Attach", "order", "ids=" + checksummed.Attach("ord", 42), nil},
+		{"unknown entity type", "post", "ids=", prefixid.ErrUnknownEntityType},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := registry.AppendPrefixID([]byte("ids="), tc.entityType, 42)
+			if !errors.Is(err, tc.err) {
+				t.Errorf("Expected error %v, got %v", tc.err, err)
+			}
+
+			if string(result) != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestID_AppendText(t *testing.T) {
+	text, err := prefixid.NewID[User](42).AppendText([]byte("id="))
+	if err != nil || string(text) != "id=usr_42" {
+		t.Errorf("Expected 'id=usr_42', got %s (err=%v)", text, err)
+	}
+
+	text, err = prefixid.NewID[Ticket]("abc").AppendText([]byte("id="))
+	if err != nil || string(text) != "id=tkt:abc" {
+		t.Errorf("Expected 'id=tkt:abc', got %s (err=%v)", text, err)
+	}
+}
+
+func TestAppendPrefixed_Allocs(t *testing.T) {
+	uuidRegistry := prefixid.NewRegistry[uuid.UUID]()
+	uuidRegistry.Register("order", "ord", prefixid.UUIDPrefixer{})
+	ulidRegistry := prefixid.NewRegistry[ulid.ULID]()
+	ulidRegistry.Register("session", "ses", prefixid.ULIDPrefixer{})
+	ksuidRegistry := prefixid.NewRegistry[ksuid.KSUID]()
+	ksuidRegistry.Register("transaction", "txn", prefixid.KSUIDPrefixer{})
+	intRegistry := prefixid.NewRegistry[int]()
+	intRegistry.Register("user", "usr", prefixid.IntPrefixer{})
+
+	buf := make([]byte, 0, 64)
+	testCases := []struct {
+		name string
+		fn   func()
+	}{
+		{"uuid append", func() { uuidRegistry.AppendPrefixID(buf, "order", appendUUID) }},
+		{"uuid parse", func() { uuidRegistry.ParsePrefixedID("order", "ord_f47ac10b-58cc-0372-8567-0e02b2c3d479") }},
+		{"ulid append", func() { ulidRegistry.AppendPrefixID(buf, "session", appendULID) }},
+		{"ulid parse", func() { ulidRegistry.ParsePrefixedID("session", "ses_01ARZ3NDEKTSV4RRFFQ69G5FAV") }},
+		{"ksuid append", func() { ksuidRegistry.AppendPrefixID(buf, "transaction", appendKSUID) }},
+		{"ksuid parse", func() { ksuidRegistry.ParsePrefixedID("transaction", "txn_0ujtsYcgvSTl8PAuAdqWYSMnLOv") }},
+		{"int append", func() { intRegistry.AppendPrefixID(buf, "user", 42) }},
+		{"int parse", func() { intRegistry.ParsePrefixedID("user", "usr_42") }},
+		{"id append text", func() { prefixid.NewID[User](42).AppendText(buf) }},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if allocs := testing.AllocsPerRun(100, tc.fn); allocs != 0 {
+				t.Errorf("Expected 0 allocs, got %v", allocs)
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package prefixid_test
+
+import (
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jasonKoogler/prefixid"
+	"github.com/oklog/ulid/v2"
+)
+
+func setupCatalog() *prefixid.Catalog {
+	catalog := prefixid.NewCatalog()
+	prefixid.CatalogRegister[string](catalog, "user", "usr", prefixid.StringPrefixer{})
+	prefixid.CatalogRegister[int](catalog, "product", "prd", prefixid.IntPrefixer{})
+	prefixid.CatalogRegister[uuid.UUID](catalog, "order", "ord", prefixid.UUIDPrefixer{})
+	prefixid.CatalogRegister[ulid.ULID](catalog, "session", "ses", prefixid.ULIDPrefixer{})
+	return catalog
+}
+
+func TestCatalog_GetEntityTypes(t *testing.T) {
+	types := setupCatalog().GetEntityTypes()
+	sort.Strings(types)
+
+	expected := []string{"order", "product", "session", "user"}
+	if len(types) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, types)
+	}
+
+	for i := range expected {
+		if types[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, types)
+		}
+	}
+}
+
+func TestCatalog_PrefixIDAndLookup(t *testing.T) {
+	catalog := setupCatalog()
+	sessionID := ulid.MustParse("01F8MECHZX3TBDSZ9PT3RV4ZMH")
+
+	prefixedID, err := prefixid.CatalogPrefixID(catalog, "session", sessionID)
+	if err != nil {
+		t.Fatalf("Failed to prefix ID: %v", err)
+	}
+
+	if prefixedID != "ses_01F8MECHZX3TBDSZ9PT3RV4ZMH" {
+		t.Errorf("Expected 'ses_01F8MECHZX3TBDSZ9PT3RV4ZMH', got %s", prefixedID)
+	}
+
+	parsed, err := prefixid.Lookup[ulid.ULID](catalog, "session", prefixedID)
+	if err != nil {
+		t.Fatalf("Failed to look up ID: %v", err)
+	}
+
+	if parsed != sessionID {
+		t.Errorf("Expected %s, got %s", sessionID, parsed)
+	}
+
+	productID, err := prefixid.Lookup[int](catalog, "product", "prd_42")
+	if err != nil || productID != 42 {
+		t.Errorf("Expected 42, got %d (err=%v)", productID, err)
+	}
+}
+
+func TestCatalog_Errors(t *testing.T) {
+	catalog := setupCatalog()
+
+	if _, err := prefixid.CatalogPrefixID(catalog, "session", "abc"); !errors.Is(err, prefixid.ErrTypeMismatch) {
+		t.Errorf("Expected ErrTypeMismatch, got %v", err)
+	}
+
+	if _, err := prefixid.Lookup[uuid.UUID](catalog, "session", "ses_01F8MECHZX3TBDSZ9PT3RV4ZMH"); !errors.Is(err, prefixid.ErrTypeMismatch) {
+		t.Errorf("Expected ErrTypeMismatch, got %v", err)
+	}
+
+	if _, err := prefixid.CatalogPrefixID(catalog, "invoice", 1); !errors.Is(err, prefixid.ErrUnknownEntityType) {
+		t.Errorf("Expected ErrUnknownEntityType, got %v", err)
+	}
+
+	if _, err := prefixid.Lookup[int](catalog, "invoice", "inv_1"); !errors.Is(err, prefixid.ErrUnknownEntityType) {
+		t.Errorf("Expected ErrUnknownEntityType, got %v", err)
+	}
+
+	if _, err := prefixid.Lookup[int](catalog, "product", "usr_1"); !errors.Is(err, prefixid.ErrPrefixMismatch) {
+		t.Errorf("Expected ErrPrefixMismatch, got %v", err)
+	}
+
+	if _, err := prefixid.Lookup[int](catalog, "product", "prd_x"); !errors.Is(err, prefixid.ErrMalformedID) {
+		t.Errorf("Expected ErrMalformedID, got %v", err)
+	}
+}
+
+func TestCatalog_Resolve(t *testing.T) {
+	catalog := setupCatalog()
+
+	testCases := []struct {
+		prefixedID     string
+		expectedEntity string
+		expectedID     any
+	}{
+		{"usr_abc", "user", "abc"},
+		{"prd_42", "product", 42},
+		{"ord_f47ac10b-58cc-0372-8567-0e02b2c3d479", "order", uuid.MustParse("f47ac10b-58cc-0372-8567-0e02b2c3d479")},
+		{"ses_01F8MECHZX3TBDSZ9PT3RV4ZMH", "session", ulid.MustParse("01F8MECHZX3TBDSZ9PT3RV4ZMH")},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.prefixedID, func(t *testing.T) {
+			entityType, id, err := catalog.Resolve(tc.prefixedID)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if entityType != tc.expectedEntity {
+				t.Errorf("Expected entity type %s, got %s", tc.expectedEntity, entityType)
+			}
+
+			if id != tc.expectedID {
+				t.Errorf("Expected %v (%T), got %v (%T)", tc.expectedID, tc.expectedID, id, id)
+			}
+		})
+	}
+
+	if _, _, err := catalog.Resolve("inv_123"); !errors.Is(err, prefixid.ErrUnknownPrefix) {
+		t.Errorf("Expected ErrUnknownPrefix, got %v", err)
+	}
+
+	entityType, _, err := catalog.Resolve("ses_not-a-ulid")
+	if !errors.Is(err, prefixid.ErrMalformedID) || entityType != "session" {
+		t.Errorf("Expected ErrMalformedID for session, got %s, %v", entityType, err)
+	}
+}
+
+func TestCatalog_MatchPrefix(t *testing.T) {
+	catalog := setupCatalog()
+	prefixid.CatalogRegister[string](catalog, "user_session", "usrs", prefixid.StringPrefixer{})
+
+	entityType, rawID, ok := catalog.MatchPrefix("usrs_1")
+	if !ok || entityType != "user_session" || rawID != "1" {
+		t.Errorf("Expected (user_session, 1), got (%s, %s, %v)", entityType, rawID, ok)
+	}
+
+	if _, _, ok := catalog.MatchPrefix("inv_1"); ok {
+		t.Error("Expected not to match prefix, but did")
+	}
+}
+
+func TestCatalog_RegisterAlias(t *testing.T) {
+	catalog := setupCatalog()
+	if err := catalog.RegisterAlias("product", "prod", "product"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if id, err := prefixid.Lookup[int](catalog, "product", "prod_42"); err != nil || id != 42 {
+		t.Errorf("Expected 42, got %d (err=%v)", id, err)
+	}
+
+	if entityType, id, err := catalog.Resolve("product_7"); err != nil || entityType != "product" || id != 7 {
+		t.Errorf("Expected (product, 7), got (%s, %v, %v)", entityType, id, err)
+	}
+
+	if prefixedID, _ := prefixid.CatalogPrefixID(catalog, "product", 42); prefixedID != "prd_42" {
+		t.Errorf("Expected prd_42, got %s", prefixedID)
+	}
+
+	prefixid.CatalogRegister[int](catalog, "product", "prd", prefixid.IntPrefixer{})
+	if aliases := catalog.Aliases("product"); len(aliases) != 2 {
+		t.Errorf("Expected re-registering to keep the aliases, got %v", aliases)
+	}
+
+	if err := catalog.RegisterAlias("product", "prd"); !errors.Is(err, prefixid.ErrDuplicatePrefix) {
+		t.Errorf("Expected ErrDuplicatePrefix, got %v", err)
+	}
+
+	if err := catalog.RegisterAlias("invoice", "inv"); !errors.Is(err, prefixid.ErrUnknownEntityType) {
+		t.Errorf("Expected ErrUnknownEntityType, got %v", err)
+	}
+}
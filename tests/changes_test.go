@@ -0,0 +1,200 @@
+package prefixid_test
+
+import (
+	"errors"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/jasonKoogler/prefixid"
+)
+
+func TestRegistry_Lookup(t *testing.T) {
+	registry := prefixid.NewRegistry[string]()
+	registry.Register("user", "usr", prefixid.StringPrefixer{})
+
+	prefix, prefixer, ok := registry.Lookup("user")
+	if !ok || prefix != "usr" || prefixer == nil {
+		t.Errorf("Expected usr with a prefixer, got %s %v (ok=%v)", prefix, prefixer, ok)
+	}
+
+	if _, _, ok := registry.Lookup("order"); ok {
+		t.Error("Expected unknown entity type not to be found")
+	}
+
+	prefixesOnly := prefixid.NewRegistryWithPrefixes[string](map[string]string{"user": "usr"})
+	if prefix, prefixer, ok := prefixesOnly.Lookup("user"); !ok || prefix != "usr" || prefixer != nil {
+		t.Errorf("Expected usr without a prefixer, got %s %v (ok=%v)", prefix, prefixer, ok)
+	}
+}
+
+func TestRegistry_Unregister(t *testing.T) {
+	registry := prefixid.NewRegistry[string]()
+	registry.Register("user", "usr", prefixid.StringPrefixer{})
+	registry.RegisterAlias("user", "u")
+
+	if err := registry.Unregister("user"); err != nil {
+		t.Fatalf("Failed to unregister: %v", err)
+	}
+
+	if _, err := registry.PrefixID("user", "abc"); !errors.Is(err, prefixid.ErrUnknownEntityType) {
+		t.Errorf("Expected ErrUnknownEntityType, got %v", err)
+	}
+
+	for _, input := range []string{"usr_abc", "u_abc"} {
+		if _, _, ok := registry.MatchPrefix(input); ok {
+			t.Errorf("Expected %s not to match", input)
+		}
+	}
+
+	if err := registry.Unregister("user"); !errors.Is(err, prefixid.ErrUnknownEntityType) {
+		t.Errorf("Expected ErrUnknownEntityType, got %v", err)
+	}
+
+	// The prefix can be reused
+	if err := registry.TryRegister("account", "usr", prefixid.StringPrefixer{}); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestRegistry_Apply(t *testing.T) {
+	registry := prefixid.NewRegistry[string]()
+	registry.MustRegister("user", "usr", prefixid.StringPrefixer{})
+	registry.MustRegister("order", "ord", prefixid.StringPrefixer{})
+
+	// Rename user to account and move its prefix
+	err := registry.Apply(
+		prefixid.Change[string]{EntityType: "user", Remove: true},
+		prefixid.Change[string]{EntityType: "account", Entry: prefixid.Entry[string]{
+			Prefix:   "acct",
+			Prefixer: prefixid.StringPrefixer{},
+			Aliases:  []string{"usr"},
+		}},
+		prefixid.Change[string]{EntityType: "order", Entry: prefixid.Entry[string]{Prefix: "ordr", Prefixer: prefixid.StringPrefixer{}}},
+	)
+	if err != nil {
+		t.Fatalf("Failed to apply changes: %v", err)
+	}
+
+	types := registry.GetEntityTypes()
+	slices.Sort(types)
+	if !slices.Equal(types, []string{"account", "order"}) {
+		t.Errorf("Expected [account order], got %v", types)
+	}
+
+	if id, err := registry.ParsePrefixedID("account", "usr_abc"); err != nil || id != "abc" {
+		t.Errorf("Expected abc, got %s (err=%v)", id, err)
+	}
+
+	if prefixedID, _ := registry.PrefixID("order", "abc"); prefixedID != "ordr_abc" {
+		t.Errorf("Expected ordr_abc, got %s", prefixedID)
+	}
+}
+
+func TestRegistry_ApplyIsAllOrNothing(t *testing.T) {
+	testCases := []struct {
+		name        string
+		changes     []prefixid.Change[string]
+		expectedErr error
+	}{
+		{
+			"remove unknown",
+			[]prefixid.Change[string]{{EntityType: "customer", Remove: true}},
+			prefixid.ErrUnknownEntityType,
+		},
+		{
+			"invalid prefix",
+			[]prefixid.Change[string]{{EntityType: "customer", Entry: prefixid.Entry[string]{Prefix: "Cust!", Prefixer: prefixid.StringPrefixer{}}}},
+			prefixid.ErrInvalidPrefix,
+		},
+		{
+			"no prefixer",
+			[]prefixid.Change[string]{{EntityType: "customer", Entry: prefixid.Entry[string]{Prefix: "cust"}}},
+			prefixid.ErrNoPrefixer,
+		},
+		{
+			"duplicate prefix",
+			[]prefixid.Change[string]{{EntityType: "customer", Entry: prefixid.Entry[string]{Prefix: "ord", Prefixer: prefixid.StringPrefixer{}}}},
+			prefixid.ErrDuplicatePrefix,
+		},
+		{
+			"conflict with an earlier change",
+			[]prefixid.Change[string]{
+				{EntityType: "customer", Entry: prefixid.Entry[string]{Prefix: "cust", Prefixer: prefixid.StringPrefixer{}}},
+				{EntityType: "user", Remove: true},
+				{EntityType: "client", Entry: prefixid.Entry[string]{Prefix: "cli", Prefixer: prefixid.StringPrefixer{}, Aliases: []string{"cust"}}},
+			},
+			prefixid.ErrDuplicatePrefix,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			registry := prefixid.NewRegistry[string]()
+			registry.MustRegister("user", "usr", prefixid.StringPrefixer{})
+			registry.MustRegister("order", "ord", prefixid.StringPrefixer{})
+
+			if err := registry.Apply(tc.changes...); !errors.Is(err, tc.expectedErr) {
+				t.Errorf("Expected %v, got %v", tc.expectedErr, err)
+			}
+
+			types := registry.GetEntityTypes()
+			slices.Sort(types)
+			if !slices.Equal(types, []string{"order", "user"}) {
+				t.Errorf("Expected the registry to be unchanged, got %v", types)
+			}
+		})
+	}
+}
+
+func TestRegistry_ReplaceIsAtomic(t *testing.T) {
+	configs := []map[string]prefixid.Entry[string]{
+		{
+			"user":  {Prefix: "usr", Prefixer: prefixid.StringPrefixer{}},
+			"order": {Prefix: "ord", Prefixer: prefixid.StringPrefixer{}},
+		},
+		{
+			"customer": {Prefix: "cust", Prefixer: prefixid.StringPrefixer{}},
+			"invoice":  {Prefix: "inv", Prefixer: prefixid.StringPrefixer{}},
+			"user":     {Prefix: "u", Prefixer: prefixid.StringPrefixer{}},
+		},
+	}
+
+	registry := prefixid.NewRegistryWithEntries(configs[0])
+
+	var wg sync.WaitGroup
+	done := make(chan struct{})
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 200; i++ {
+			if err := registry.Replace(configs[i%2]); err != nil {
+				t.Errorf("Failed to replace: %v", err)
+				break
+			}
+		}
+		close(done)
+	}()
+
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+
+				if n := len(registry.GetEntityTypes()); n != 2 && n != 3 {
+					t.Errorf("Expected a complete config, got %d entity types", n)
+					return
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+}
@@ -0,0 +1,183 @@
+package prefixid_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jasonKoogler/prefixid"
+)
+
+func TestChecksumPrefixer_Attach(t *testing.T) {
+	testCases := []struct {
+		name      string
+		algorithm prefixid.ChecksumAlgorithm
+		expected  string
+	}{
+		{"mod 37", prefixid.ChecksumMod37, "usr_425"},
+		{"crc32", prefixid.ChecksumCRC32, "usr_429C48"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			prefixer := prefixid.NewChecksumPrefixer[int](prefixid.IntPrefixer{}, tc.algorithm)
+			if result := prefixer.Attach("usr", 42); result != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestChecksumPrefixer_RoundTrip(t *testing.T) {
+	id := uuid.MustParse("f47ac10b-58cc-0372-8567-0e02b2c3d479")
+
+	for _, algorithm := range []prefixid.ChecksumAlgorithm{prefixid.ChecksumMod37, prefixid.ChecksumCRC32} {
+		registry := prefixid.NewRegistry[uuid.UUID]()
+		registry.Register("user", "usr", prefixid.NewChecksumPrefixer[uuid.UUID](prefixid.UUIDPrefixer{}, algorithm))
+
+		prefixedID, err := registry.PrefixID("user", id)
+		if err != nil {
+			t.Fatalf("Failed to prefix ID: %v", err)
+		}
+
+		parsed, err := registry.ParsePrefixedID("user", prefixedID)
+		if err != nil || parsed != id {
+			t.Errorf("Algorithm %d: expected %s, got %s (err=%v)", algorithm, id, parsed, err)
+		}
+
+		entityType, resolved, err := registry.Resolve(prefixedID)
+		if err != nil || entityType != "user" || resolved != id {
+			t.Errorf("Algorithm %d: expected user %s, got %s %s (err=%v)", algorithm, id, entityType, resolved, err)
+		}
+	}
+}
+
+func TestChecksumPrefixer_CatchesTypos(t *testing.T) {
+	prefixer := prefixid.NewChecksumPrefixer[uuid.UUID](prefixid.UUIDPrefixer{}, prefixid.ChecksumMod37)
+	prefixedID := prefixer.Attach("usr", uuid.MustParse("f47ac10b-58cc-0372-8567-0e02b2c3d479"))
+	const hexDigits = "0123456789abcdef"
+
+	body := []byte(prefixedID[:len(prefixedID)-1])
+	check := prefixedID[len(prefixedID)-1:]
+	start := len("usr_")
+
+	assertMismatch := func(typo string) {
+		t.Helper()
+
+		if rawID, ok := prefixer.Detach("usr", typo); ok {
+			t.Fatalf("Expected %s not to detach, got %s", typo, rawID)
+		}
+
+		if err := prefixer.Verify("usr", typo); !errors.Is(err, prefixid.ErrChecksumMismatch) {
+			t.Errorf("Expected ErrChecksumMismatch for %s, got %v", typo, err)
+		}
+	}
+
+	// Every single hex digit substitution
+	for i := start; i < len(body); i++ {
+		if body[i] == '-' {
+			continue
+		}
+
+		for j := 0; j < len(hexDigits); j++ {
+			if hexDigits[j] == body[i] {
+				continue
+			}
+
+			typo := append([]byte(nil), body...)
+			typo[i] = hexDigits[j]
+			assertMismatch(string(typo) + check)
+		}
+	}
+
+	// Every transposition of adjacent, different digits
+	for i := start; i < len(body)-1; i++ {
+		if body[i] == body[i+1] || body[i] == '-' || body[i+1] == '-' {
+			continue
+		}
+
+		typo := append([]byte(nil), body...)
+		typo[i], typo[i+1] = typo[i+1], typo[i]
+		assertMismatch(string(typo) + check)
+	}
+}
+
+func TestChecksumPrefixer_UppercaseUUID(t *testing.T) {
+	for _, algorithm := range []prefixid.ChecksumAlgorithm{prefixid.ChecksumMod37, prefixid.ChecksumCRC32} {
+		prefixer := prefixid.NewChecksumPrefixer[uuid.UUID](prefixid.UUIDPrefixer{}, algorithm)
+
+		for i := 0; i < 200; i++ {
+			id := uuid.New()
+			prefixedID := "usr_" + strings.ToUpper(strings.TrimPrefix(prefixer.Attach("usr", id), "usr_"))
+
+			rawID, ok := prefixer.Detach("usr", prefixedID)
+			if !ok {
+				t.Fatalf("Algorithm %d: failed to detach %s", algorithm, prefixedID)
+			}
+
+			if parsed, err := prefixer.Parse(rawID); err != nil || parsed != id {
+				t.Errorf("Algorithm %d: expected %s, got %s (err=%v)", algorithm, id, parsed, err)
+			}
+		}
+	}
+}
+
+func TestChecksumPrefixer_Detach(t *testing.T) {
+	prefixer := prefixid.NewChecksumPrefixer[int](prefixid.IntPrefixer{}, prefixid.ChecksumCRC32)
+
+	testCases := []struct {
+		name        string
+		input       string
+		expected    string
+		expectedErr error
+	}{
+		{"valid", "usr_429C48", "42", nil},
+		{"lowercase check", "usr_429c48", "42", nil},
+		{"wrong check", "usr_429C49", "", prefixid.ErrChecksumMismatch},
+		{"transposed digits", "usr_249C48", "", prefixid.ErrChecksumMismatch},
+		{"invalid check character", "usr_429C4!", "", prefixid.ErrChecksumMismatch},
+		{"no check", "usr_4", "", prefixid.ErrChecksumMismatch},
+		{"other prefix", "ord_429C48", "", nil},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rawID, ok := prefixer.Detach("usr", tc.input)
+			if ok != (tc.expected != "") || rawID != tc.expected {
+				t.Errorf("Expected %q, got %q (ok=%v)", tc.expected, rawID, ok)
+			}
+
+			if err := prefixer.Verify("usr", tc.input); !errors.Is(err, tc.expectedErr) {
+				t.Errorf("Expected %v, got %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestChecksumPrefixer_RegistryErrors(t *testing.T) {
+	registry := prefixid.NewRegistry[int]()
+	registry.Register("customer", "cust", prefixid.NewChecksumPrefixer[int](prefixid.IntPrefixer{}, prefixid.ChecksumMod37))
+
+	_, err := registry.ParsePrefixedID("customer", "cust_245")
+	if !errors.Is(err, prefixid.ErrChecksumMismatch) || !errors.Is(err, prefixid.ErrMalformedID) {
+		t.Errorf("Expected ErrChecksumMismatch and ErrMalformedID, got %v", err)
+	}
+
+	_, err = registry.ParsePrefixedID("customer", "usr_425")
+	if !errors.Is(err, prefixid.ErrPrefixMismatch) || errors.Is(err, prefixid.ErrMalformedID) {
+		t.Errorf("Expected only ErrPrefixMismatch, got %v", err)
+	}
+
+	if entityType, rawID, ok := registry.MatchPrefix("cust_425"); !ok || entityType != "customer" || rawID != "42" {
+		t.Errorf("Expected (customer, 42), got (%s, %s, %v)", entityType, rawID, ok)
+	}
+
+	if _, _, ok := registry.MatchPrefix("cust_435"); ok {
+		t.Error("Expected cust_435 not to match")
+	}
+
+	if _, _, err := registry.Resolve("cust_435"); !errors.Is(err, prefixid.ErrUnknownPrefix) {
+		t.Errorf("Expected ErrUnknownPrefix, got %v", err)
+	}
+}
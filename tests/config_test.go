@@ -0,0 +1,216 @@
+package prefixid_test
+
+import (
+	"errors"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/google/uuid"
+	"github.com/jasonKoogler/prefixid"
+	"github.com/oklog/ulid/v2"
+)
+
+func TestLoadConfig(t *testing.T) {
+	expected := prefixid.Config{Entities: []prefixid.EntityConfig{
+		{Entity: "user", Prefix: "usr", Kind: prefixid.KindUUID, Aliases: []string{"user"}, Description: "Registered users"},
+		{Entity: "order", Prefix: "ord", Kind: prefixid.KindUUID, Description: "Orders placed by users"},
+	}}
+
+	for _, name := range []string{"entities.yaml", "entities.json"} {
+		t.Run(name, func(t *testing.T) {
+			config, err := prefixid.LoadConfig(os.DirFS("testdata/config"), name)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(config, expected) {
+				t.Errorf("Expected %+v, got %+v", expected, config)
+			}
+		})
+	}
+}
+
+func TestLoadConfig_Errors(t *testing.T) {
+	fsys := fstest.MapFS{
+		"entities.toml":   {Data: []byte(`[entities]`)},
+		"unknown.yaml":    {Data: []byte("entities:\n  - entity: user\n    prefx: usr\n")},
+		"unknown.json":    {Data: []byte(`{"entities": [{"entity": "user", "prefx": "usr"}]}`)},
+		"malformed.json":  {Data: []byte(`{"entities": [`)},
+		"malformed.yml":   {Data: []byte("entities: [")},
+		"wrong-type.yaml": {Data: []byte("entities: user")},
+	}
+
+	testCases := []struct {
+		name string
+		err  error
+	}{
+		{"entities.toml", prefixid.ErrInvalidConfig},
+		{"unknown.yaml", prefixid.ErrInvalidConfig},
+		{"unknown.json", prefixid.ErrInvalidConfig},
+		{"malformed.json", prefixid.ErrInvalidConfig},
+		{"malformed.yml", prefixid.ErrInvalidConfig},
+		{"wrong-type.yaml", prefixid.ErrInvalidConfig},
+		{"missing.yaml", os.ErrNotExist},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := prefixid.LoadConfig(fsys, tc.name); !errors.Is(err, tc.err) {
+				t.Errorf("Expected %v, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestReadConfig_EmptyAndTrailingInput(t *testing.T) {
+	testCases := []struct {
+		name  string
+		read  func(io.Reader) (prefixid.Config, error)
+		input string
+	}{
+		{"empty yaml", prefixid.ReadYAMLConfig, ""},
+		{"blank yaml", prefixid.ReadYAMLConfig, "\n# no entities\n"},
+		{"second yaml document", prefixid.ReadYAMLConfig, "entities: []\n---\nentities: []\n"},
+		{"empty json", prefixid.ReadJSONConfig, ""},
+		{"blank json", prefixid.ReadJSONConfig, " \n"},
+		{"trailing json", prefixid.ReadJSONConfig, `{"entities": []} garbage`},
+		{"second json value", prefixid.ReadJSONConfig, `{"entities": []} {}`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := tc.read(strings.NewReader(tc.input)); !errors.Is(err, prefixid.ErrInvalidConfig) {
+				t.Errorf("Expected ErrInvalidConfig, got %v", err)
+			}
+		})
+	}
+
+	if config, err := prefixid.ReadJSONConfig(strings.NewReader(`{"entities": []}` + "\n")); err != nil || len(config.Entities) != 0 {
+		t.Errorf("Expected an empty config, got %+v (err=%v)", config, err)
+	}
+
+	if config, err := prefixid.ReadYAMLConfig(strings.NewReader("entities: []\n")); err != nil || len(config.Entities) != 0 {
+		t.Errorf("Expected an empty config, got %+v (err=%v)", config, err)
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	config := prefixid.Config{Entities: []prefixid.EntityConfig{
+		{Entity: "user", Prefix: "usr", Kind: prefixid.KindUUID},
+		{Prefix: "anon", Kind: prefixid.KindUUID},
+		{Entity: "user", Prefix: "usr2", Kind: prefixid.KindUUID},
+		{Entity: "order", Prefix: "ord", Kind: "uuid4"},
+		{Entity: "account", Prefix: "usr", Kind: prefixid.KindInt},
+		{Entity: "post", Prefix: "Post", Kind: prefixid.KindString},
+		{Entity: "comment", Prefix: "cmt", Kind: prefixid.KindString, Aliases: []string{"cmt"}},
+		{Entity: "session", Prefix: "us", Kind: prefixid.KindULID},
+	}}
+
+	err := config.Validate(prefixid.DefaultPrefixPolicy)
+	for _, target := range []error{
+		prefixid.ErrInvalidConfig,
+		prefixid.ErrDuplicatePrefix,
+		prefixid.ErrInvalidPrefix,
+		prefixid.ErrPrefixOverlap,
+	} {
+		if !errors.Is(err, target) {
+			t.Errorf("Expected error matching %v, got %v", target, err)
+		}
+	}
+
+	for _, problem := range []string{"entity 2 has no name", "user is defined more than once", `unknown kind "uuid4"`, "entity comment"} {
+		if err == nil || !strings.Contains(err.Error(), problem) {
+			t.Errorf("Expected error to mention %q, got %v", problem, err)
+		}
+	}
+}
+
+func TestNewRegistryFromConfig(t *testing.T) {
+	config, err := prefixid.LoadConfig(os.DirFS("testdata/config"), "entities.yaml")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	registry, err := prefixid.NewRegistryFromConfig[uuid.UUID](config)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	id := uuid.MustParse("f47ac10b-58cc-0372-8567-0e02b2c3d479")
+	if prefixedID, _ := registry.PrefixID("order", id); prefixedID != "ord_"+id.String() {
+		t.Errorf("Expected ord_%s, got %s", id, prefixedID)
+	}
+
+	if parsed, err := registry.ParsePrefixedID("user", "user_"+id.String()); err != nil || parsed != id {
+		t.Errorf("Expected %s, got %s (err=%v)", id, parsed, err)
+	}
+
+	if _, err := prefixid.NewRegistryFromConfig[int](config); !errors.Is(err, prefixid.ErrTypeMismatch) {
+		t.Errorf("Expected ErrTypeMismatch, got %v", err)
+	}
+
+	strict := prefixid.WithPrefixPolicy(prefixid.PrefixPolicy{MinLength: 4})
+	if _, err := prefixid.NewRegistryFromConfig[uuid.UUID](config, strict); !errors.Is(err, prefixid.ErrInvalidPrefix) {
+		t.Errorf("Expected ErrInvalidPrefix, got %v", err)
+	}
+}
+
+func TestNewCatalogFromConfig(t *testing.T) {
+	config := prefixid.Config{Entities: []prefixid.EntityConfig{
+		{Entity: "user", Prefix: "usr", Kind: prefixid.KindUUID, Aliases: []string{"user"}},
+		{Entity: "session", Prefix: "ses", Kind: prefixid.KindULID},
+		{Entity: "invoice", Prefix: "inv", Kind: prefixid.KindInt, Aliases: []string{"invoice"}},
+	}}
+
+	catalog, err := prefixid.NewCatalogFromConfig(config)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if entityType, id, err := catalog.Resolve("inv_42"); err != nil || entityType != "invoice" || id != 42 {
+		t.Errorf("Expected (invoice, 42), got (%s, %v, %v)", entityType, id, err)
+	}
+
+	sessionID := ulid.MustParse("01ARZ3NDEKTSV4RRFFQ69G5FAV")
+	if id, err := prefixid.Lookup[ulid.ULID](catalog, "session", "ses_"+sessionID.String()); err != nil || id != sessionID {
+		t.Errorf("Expected %s, got %s (err=%v)", sessionID, id, err)
+	}
+
+	userID := uuid.MustParse("f47ac10b-58cc-0372-8567-0e02b2c3d479")
+	if id, err := prefixid.Lookup[uuid.UUID](catalog, "user", "user_"+userID.String()); err != nil || id != userID {
+		t.Errorf("Expected %s, got %s (err=%v)", userID, id, err)
+	}
+
+	if entityType, id, err := catalog.Resolve("invoice_7"); err != nil || entityType != "invoice" || id != 7 {
+		t.Errorf("Expected (invoice, 7), got (%s, %v, %v)", entityType, id, err)
+	}
+}
+
+func TestConfigEntries(t *testing.T) {
+	config := prefixid.Config{Entities: []prefixid.EntityConfig{
+		{Entity: "user", Prefix: "usr", Kind: prefixid.KindUUID, Aliases: []string{"user"}},
+		{Entity: "invoice", Prefix: "inv", Kind: prefixid.KindInt},
+		{Entity: "order", Prefix: "ord", Kind: prefixid.KindUUID},
+	}}
+
+	entries := prefixid.ConfigEntries[uuid.UUID](config)
+	if len(entries) != 2 || entries["user"].Prefix != "usr" || entries["order"].Prefix != "ord" {
+		t.Errorf("Expected the user and order entries, got %+v", entries)
+	}
+
+	if aliases := entries["user"].Aliases; !reflect.DeepEqual(aliases, []string{"user"}) {
+		t.Errorf("Expected [user], got %v", aliases)
+	}
+
+	if entries := prefixid.ConfigEntries[int](config); len(entries) != 1 || entries["invoice"].Prefix != "inv" {
+		t.Errorf("Expected the invoice entry, got %+v", entries)
+	}
+
+	if entries := prefixid.ConfigEntries[string](config); len(entries) != 0 {
+		t.Errorf("Expected no entries, got %+v", entries)
+	}
+}
@@ -0,0 +1,150 @@
+package prefixid_test
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jasonKoogler/prefixid"
+	"github.com/oklog/ulid/v2"
+)
+
+func TestEncryptedPrefixer_RoundTrip(t *testing.T) {
+	keyring := newTestKeyring(t)
+
+	t.Run("uuid", func(t *testing.T) {
+		prefixer := prefixid.NewEncryptedPrefixer[uuid.UUID](keyring)
+		id := uuid.MustParse("f47ac10b-58cc-0372-8567-0e02b2c3d479")
+
+		prefixedID := prefixer.Attach("inv", id)
+		if len(prefixedID) != len("inv_")+27 || !strings.HasPrefix(prefixedID, "inv_k") {
+			t.Errorf("Expected inv_k and 26 characters, got %s", prefixedID)
+		}
+
+		rawID, _ := prefixer.Detach("inv", prefixedID)
+		parsed, err := prefixer.Parse(rawID)
+		if err != nil || parsed != id {
+			t.Errorf("Expected %s, got %s (err=%v)", id, parsed, err)
+		}
+	})
+
+	t.Run("ulid", func(t *testing.T) {
+		prefixer := prefixid.NewEncryptedPrefixer[ulid.ULID](keyring)
+		id := ulid.MustParse("01F8MECHZX3TBDSZ9PT3RV4ZMH")
+
+		prefixedID := prefixer.Attach("ses", id)
+		if strings.Contains(prefixedID, strings.ToLower(id.String()[:10])) {
+			t.Errorf("Expected the ULID timestamp to be hidden, got %s", prefixedID)
+		}
+
+		rawID, _ := prefixer.Detach("ses", prefixedID)
+		parsed, err := prefixer.Parse(rawID)
+		if err != nil || parsed != id {
+			t.Errorf("Expected %s, got %s (err=%v)", id, parsed, err)
+		}
+	})
+
+	t.Run("integers", func(t *testing.T) {
+		prefixer := prefixid.NewEncryptedIntegerPrefixer[int64](keyring)
+		for _, id := range []int64{0, 1, 2, 42, -1, math.MaxInt64, math.MinInt64} {
+			rawID, _ := prefixer.Detach("inv", prefixer.Attach("inv", id))
+			parsed, err := prefixer.Parse(rawID)
+			if err != nil || parsed != id {
+				t.Errorf("Expected %d, got %d (err=%v)", id, parsed, err)
+			}
+		}
+
+		small := prefixid.NewEncryptedIntegerPrefixer[int8](keyring)
+		rawID, _ := small.Detach("inv", small.Attach("inv", -5))
+		if parsed, err := small.Parse(rawID); err != nil || parsed != -5 {
+			t.Errorf("Expected -5, got %d (err=%v)", parsed, err)
+		}
+	})
+}
+
+func TestEncryptedIntegerPrefixer_HidesOrder(t *testing.T) {
+	prefixer := prefixid.NewEncryptedIntegerPrefixer[int64](newTestKeyring(t))
+
+	first, second := prefixer.Attach("inv", 1), prefixer.Attach("inv", 2)
+	common := 0
+	for common < len(first) && first[common] == second[common] {
+		common++
+	}
+
+	// The prefix, separator and key ID are shared; the rest should differ
+	if common > len("inv_k")+2 {
+		t.Errorf("Expected unrelated IDs, got %s and %s", first, second)
+	}
+}
+
+func TestEncryptedIntegerPrefixer_Parse(t *testing.T) {
+	keyring := newTestKeyring(t)
+	prefixer := prefixid.NewEncryptedIntegerPrefixer[int64](keyring)
+	valid, _ := prefixer.Detach("inv", prefixer.Attach("inv", 42))
+
+	// Flip one character of the ciphertext
+	tampered := []byte(valid)
+	if tampered[10] == 'a' {
+		tampered[10] = 'b'
+	} else {
+		tampered[10] = 'a'
+	}
+
+	testCases := []struct {
+		name  string
+		input string
+	}{
+		{"tampered", string(tampered)},
+		{"unknown key", "z" + valid[1:]},
+		{"too short", valid[:len(valid)-1]},
+		{"invalid character", valid[:len(valid)-1] + "u"},
+		{"uppercase", strings.ToUpper(valid)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := prefixer.Parse(tc.input); !errors.Is(err, prefixid.ErrDecryptionFailed) {
+				t.Errorf("Expected ErrDecryptionFailed, got %v", err)
+			}
+		})
+	}
+
+	large := prefixid.NewEncryptedIntegerPrefixer[int64](keyring)
+	rawID, _ := large.Detach("inv", large.Attach("inv", 1000))
+	if _, err := prefixid.NewEncryptedIntegerPrefixer[uint8](keyring).Parse(rawID); !errors.Is(err, prefixid.ErrDecryptionFailed) {
+		t.Errorf("Expected ErrDecryptionFailed for 1000 as uint8, got %v", err)
+	}
+}
+
+func TestEncryptedPrefixer_KeyRotation(t *testing.T) {
+	keyring := newTestKeyring(t)
+
+	registry := prefixid.NewRegistry[int64]()
+	registry.Register("invoice", "inv", prefixid.NewEncryptedIntegerPrefixer[int64](keyring))
+
+	oldID, _ := registry.PrefixID("invoice", 42)
+
+	keyring.Add('n', rotatedKey)
+	keyring.Use('n')
+
+	newID, _ := registry.PrefixID("invoice", 42)
+	if newID == oldID || !strings.HasPrefix(newID, "inv_n") {
+		t.Errorf("Expected an ID encrypted with key n, got %s", newID)
+	}
+
+	for _, prefixedID := range []string{oldID, newID} {
+		id, err := registry.ParsePrefixedID("invoice", prefixedID)
+		if err != nil || id != 42 {
+			t.Errorf("Expected 42 from %s, got %d (err=%v)", prefixedID, id, err)
+		}
+	}
+
+	keyring.Remove('k')
+
+	_, err := registry.ParsePrefixedID("invoice", oldID)
+	if !errors.Is(err, prefixid.ErrDecryptionFailed) || !errors.Is(err, prefixid.ErrMalformedID) {
+		t.Errorf("Expected ErrDecryptionFailed and ErrMalformedID, got %v", err)
+	}
+}
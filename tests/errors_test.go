@@ -0,0 +1,100 @@
+package prefixid_test
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jasonKoogler/prefixid"
+)
+
+func TestErrors_PrefixID(t *testing.T) {
+	registry := prefixid.NewRegistryWithPrefixes[string](map[string]string{"post": "pst"})
+	registry.Register("user", "usr", prefixid.StringPrefixer{})
+
+	_, err := registry.PrefixID("comment", "123")
+	if !errors.Is(err, prefixid.ErrUnknownEntityType) {
+		t.Errorf("Expected ErrUnknownEntityType, got %v", err)
+	}
+
+	if err.Error() != "no prefix registered for entity type: comment" {
+		t.Errorf("Unexpected error message: %s", err)
+	}
+
+	_, err = registry.PrefixID("post", "123")
+	if !errors.Is(err, prefixid.ErrNoPrefixer) {
+		t.Errorf("Expected ErrNoPrefixer, got %v", err)
+	}
+}
+
+func TestErrors_ParsePrefixedID(t *testing.T) {
+	registry := prefixid.NewRegistry[uuid.UUID]()
+	registry.Register("order", "ord", prefixid.UUIDPrefixer{})
+
+	_, err := registry.ParsePrefixedID("invoice", "inv_123")
+	if !errors.Is(err, prefixid.ErrUnknownEntityType) {
+		t.Errorf("Expected ErrUnknownEntityType, got %v", err)
+	}
+
+	_, err = registry.ParsePrefixedID("order", "usr_f47ac10b-58cc-0372-8567-0e02b2c3d479")
+	if !errors.Is(err, prefixid.ErrPrefixMismatch) {
+		t.Errorf("Expected ErrPrefixMismatch, got %v", err)
+	}
+
+	if errors.Is(err, prefixid.ErrMalformedID) {
+		t.Error("Did not expect prefix mismatch to match ErrMalformedID")
+	}
+
+	var parseErr *prefixid.ParseError
+	if !errors.As(err, &parseErr) {
+		t.Fatalf("Expected *ParseError, got %T", err)
+	}
+
+	if parseErr.EntityType != "order" || parseErr.Prefix != "ord" || parseErr.Input != "usr_f47ac10b-58cc-0372-8567-0e02b2c3d479" {
+		t.Errorf("Unexpected ParseError fields: %+v", parseErr)
+	}
+
+	_, err = registry.ParsePrefixedID("order", "ord_not-a-uuid")
+	if !errors.Is(err, prefixid.ErrMalformedID) {
+		t.Errorf("Expected ErrMalformedID, got %v", err)
+	}
+
+	if !errors.As(err, &parseErr) || parseErr.Err == nil {
+		t.Errorf("Expected ParseError to wrap the uuid error, got %v", err)
+	}
+}
+
+func TestErrors_UnderlyingParserError(t *testing.T) {
+	registry := prefixid.NewRegistry[int]()
+	registry.Register("product", "prd", prefixid.IntPrefixer{})
+
+	_, err := registry.ParsePrefixedID("product", "prd_abc")
+
+	var numErr *strconv.NumError
+	if !errors.As(err, &numErr) {
+		t.Fatalf("Expected *strconv.NumError, got %v", err)
+	}
+
+	if !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("Expected strconv.ErrSyntax, got %v", err)
+	}
+}
+
+func TestErrors_ParseID(t *testing.T) {
+	_, err := prefixid.ParseID[Order, uuid.UUID]("usr_f47ac10b-58cc-0372-8567-0e02b2c3d479")
+	if !errors.Is(err, prefixid.ErrPrefixMismatch) {
+		t.Errorf("Expected ErrPrefixMismatch, got %v", err)
+	}
+
+	_, err = prefixid.ParseID[Order, uuid.UUID]("ord_xyz")
+
+	var parseErr *prefixid.ParseError
+	if !errors.As(err, &parseErr) || parseErr.Kind != prefixid.ErrMalformedID {
+		t.Fatalf("Expected malformed ID ParseError, got %v", err)
+	}
+
+	if parseErr.EntityType != "Order" {
+		t.Errorf("Expected entity type Order, got %s", parseErr.EntityType)
+	}
+}
@@ -0,0 +1,130 @@
+package prefixid_test
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jasonKoogler/prefixid"
+	"github.com/oklog/ulid/v2"
+	"github.com/segmentio/ksuid"
+)
+
+func TestFormat_Attach(t *testing.T) {
+	testCases := []struct {
+		name     string
+		format   prefixid.Format
+		expected string
+	}{
+		{"zero value", prefixid.Format{}, "usr_123"},
+		{"colon", prefixid.NewFormat(prefixid.WithSeparator(":")), "usr:123"},
+		{"hyphen", prefixid.NewFormat(prefixid.WithSeparator("-")), "usr-123"},
+		{"dot", prefixid.NewFormat(prefixid.WithSeparator(".")), "usr.123"},
+		{"upper", prefixid.NewFormat(prefixid.WithPrefixCase(prefixid.CaseUpper)), "USR_123"},
+		{"suffix", prefixid.NewFormat(prefixid.WithSuffix("@v1")), "usr_123@v1"},
+		{"all", prefixid.NewFormat(
+			prefixid.WithSeparator(":"),
+			prefixid.WithPrefixCase(prefixid.CaseUpper),
+			prefixid.WithSuffix("!"),
+		), "USR:123!"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := tc.format.Attach("usr", "123")
+			if result != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, result)
+			}
+
+			rawID, ok := tc.format.Detach("usr", result)
+			if !ok || rawID != "123" {
+				t.Errorf("Expected to detach '123', got %q (ok=%v)", rawID, ok)
+			}
+		})
+	}
+}
+
+func TestFormat_Detach(t *testing.T) {
+	testCases := []struct {
+		name       string
+		format     prefixid.Format
+		prefixedID string
+		expected   string
+		ok         bool
+	}{
+		{"wrong separator", prefixid.NewFormat(prefixid.WithSeparator(":")), "usr_123", "", false},
+		{"preserve case is exact", prefixid.Format{}, "USR_123", "", false},
+		{"lower folds", prefixid.NewFormat(prefixid.WithPrefixCase(prefixid.CaseLower)), "USR_123", "123", true},
+		{"upper folds", prefixid.NewFormat(prefixid.WithPrefixCase(prefixid.CaseUpper)), "usr_123", "123", true},
+		{"missing suffix", prefixid.NewFormat(prefixid.WithSuffix("@v1")), "usr_123", "", false},
+		{"too short for suffix", prefixid.NewFormat(prefixid.WithSuffix("@v1")), "usr_@v", "", false},
+		{"empty body", prefixid.Format{}, "usr_", "", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, ok := tc.format.Detach("usr", tc.prefixedID)
+			if ok != tc.ok {
+				t.Errorf("Expected ok=%v, got %v", tc.ok, ok)
+			}
+
+			if result != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestFormat_BuiltinPrefixers(t *testing.T) {
+	opts := []prefixid.FormatOption{prefixid.WithSeparator(":")}
+
+	uuidID := uuid.MustParse("f47ac10b-58cc-0372-8567-0e02b2c3d479")
+	ulidID := ulid.MustParse("01F8MECHZX3TBDSZ9PT3RV4ZMH")
+	ksuidID := ksuid.New()
+
+	testCases := []struct {
+		name     string
+		attach   func() string
+		expected string
+	}{
+		{"string", func() string { return prefixid.NewStringPrefixer(opts...).Attach("usr", "abc") }, "usr:abc"},
+		{"int", func() string { return prefixid.NewIntPrefixer(opts...).Attach("usr", 123) }, "usr:123"},
+		{"uuid", func() string { return prefixid.NewUUIDPrefixer(opts...).Attach("ord", uuidID) }, "ord:" + uuidID.String()},
+		{"ulid", func() string { return prefixid.NewULIDPrefixer(opts...).Attach("ses", ulidID) }, "ses:" + ulidID.String()},
+		{"ksuid", func() string { return prefixid.NewKSUIDPrefixer(opts...).Attach("txn", ksuidID) }, "txn:" + ksuidID.String()},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if result := tc.attach(); result != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestFormat_Registry(t *testing.T) {
+	registry := prefixid.NewRegistry[int]()
+	registry.Register("customer", "cust", prefixid.NewIntPrefixer(
+		prefixid.WithSeparator("-"),
+		prefixid.WithPrefixCase(prefixid.CaseUpper),
+	))
+
+	prefixedID, err := registry.PrefixID("customer", 42)
+	if err != nil {
+		t.Fatalf("Failed to prefix ID: %v", err)
+	}
+
+	if prefixedID != "CUST-42" {
+		t.Errorf("Expected 'CUST-42', got %s", prefixedID)
+	}
+
+	id, err := registry.ParsePrefixedID("customer", "cust-42")
+	if err != nil || id != 42 {
+		t.Errorf("Expected 42, got %d (err=%v)", id, err)
+	}
+
+	entityType, rawID, ok := registry.MatchPrefix(prefixedID)
+	if !ok || entityType != "customer" || rawID != "42" {
+		t.Errorf("Expected (customer, 42), got (%s, %s, %v)", entityType, rawID, ok)
+	}
+}
@@ -0,0 +1,152 @@
+package prefixid_test
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/jasonKoogler/prefixid"
+)
+
+func TestBuilder_Build(t *testing.T) {
+	var aliasUses []string
+	registry, err := prefixid.NewBuilder[int](
+		prefixid.WithScopes("test", "live"),
+		prefixid.WithAliasHook(func(entityType, alias, prefixedID string) {
+			aliasUses = append(aliasUses, alias)
+		}),
+	).
+		Register("user", "usr", prefixid.IntPrefixer{}).
+		RegisterEntry("order", prefixid.Entry[int]{
+			Prefix:    "ord",
+			Prefixer:  prefixid.NewIntPrefixer(prefixid.WithPrefixCase(prefixid.CaseLower)),
+			Generator: prefixid.GeneratorFunc[int](func() (int, error) { return 7, nil }),
+			Aliases:   []string{"order"},
+		}).
+		Build()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if types := registry.GetEntityTypes(); !slices.Equal(slices.Sorted(slices.Values(types)), []string{"order", "user"}) {
+		t.Errorf("Expected [order user], got %v", types)
+	}
+
+	if prefixedID, _ := registry.PrefixID("user", 42); prefixedID != "usr_42" {
+		t.Errorf("Expected usr_42, got %s", prefixedID)
+	}
+
+	if prefixedID, id, err := registry.New("order"); err != nil || prefixedID != "ord_7" || id != 7 {
+		t.Errorf("Expected (ord_7, 7), got (%s, %d, %v)", prefixedID, id, err)
+	}
+
+	if id, err := registry.ParsePrefixedID("order", "order_9"); err != nil || id != 9 {
+		t.Errorf("Expected 9, got %d (err=%v)", id, err)
+	}
+
+	if entityType, id, err := registry.Resolve("ORD_5"); err != nil || entityType != "order" || id != 5 {
+		t.Errorf("Expected (order, 5), got (%s, %d, %v)", entityType, id, err)
+	}
+
+	if entityType, rawID, ok := registry.MatchPrefix("order_3"); !ok || entityType != "order" || rawID != "3" {
+		t.Errorf("Expected (order, 3), got (%s, %s, %v)", entityType, rawID, ok)
+	}
+
+	if !slices.Equal(aliasUses, []string{"order", "order"}) {
+		t.Errorf("Expected the alias hook to be called twice, got %v", aliasUses)
+	}
+
+	if prefixedID, _ := registry.PrefixScopedID("user", "test", 1); prefixedID != "usr_test_1" {
+		t.Errorf("Expected usr_test_1, got %s", prefixedID)
+	}
+
+	if scope, id, err := registry.ParseScopedID("user", "usr_live_2"); err != nil || scope != "live" || id != 2 {
+		t.Errorf("Expected (live, 2), got (%s, %d, %v)", scope, id, err)
+	}
+
+	if _, _, err := registry.Resolve("pst_1"); !errors.Is(err, prefixid.ErrUnknownPrefix) {
+		t.Errorf("Expected ErrUnknownPrefix, got %v", err)
+	}
+}
+
+func TestBuilder_Errors(t *testing.T) {
+	_, err := prefixid.NewBuilder[string]().
+		Register("user", "usr", prefixid.StringPrefixer{}).
+		Register("user", "u", prefixid.StringPrefixer{}).
+		Register("account", "usr", prefixid.StringPrefixer{}).
+		Register("post", "Post", prefixid.StringPrefixer{}).
+		RegisterEntry("order", prefixid.Entry[string]{Prefix: "ord"}).
+		RegisterEntry("user", prefixid.Entry[string]{Prefix: "usr2", Prefixer: prefixid.StringPrefixer{}}).
+		Build()
+
+	expected := []error{
+		prefixid.ErrEntityTypeExists,
+		prefixid.ErrDuplicatePrefix,
+		prefixid.ErrInvalidPrefix,
+		prefixid.ErrNoPrefixer,
+	}
+	for _, target := range expected {
+		if !errors.Is(err, target) {
+			t.Errorf("Expected error matching %v, got %v", target, err)
+		}
+	}
+}
+
+func TestBuilder_BuildIsolated(t *testing.T) {
+	builder := prefixid.NewBuilder[string]().Register("user", "usr", prefixid.StringPrefixer{})
+	registry := builder.MustBuild()
+
+	builder.Register("post", "pst", prefixid.StringPrefixer{})
+	if _, _, ok := registry.Lookup("post"); ok {
+		t.Error("Expected registrations after Build not to affect the registry")
+	}
+
+	if _, _, ok := registry.MatchPrefix("pst_1"); ok {
+		t.Error("Expected pst_1 not to match")
+	}
+}
+
+func TestFrozenRegistry_MatchPrefixLikeRegistry(t *testing.T) {
+	nested := prefixid.WithPrefixPolicy(prefixid.PrefixPolicy{MinLength: 1, AllowNested: true})
+	builder := prefixid.NewBuilder[string](nested)
+	registry := prefixid.NewRegistry[string](nested)
+
+	prefixers := []prefixid.StringPrefixer{
+		{},
+		prefixid.NewStringPrefixer(prefixid.WithPrefixCase(prefixid.CaseUpper)),
+		prefixid.NewStringPrefixer(prefixid.WithSeparator(":")),
+	}
+	for i := 0; i < 500; i++ {
+		entityType := fmt.Sprintf("entity%d", i)
+		prefix := fmt.Sprintf("p%d", i)
+		builder.Register(entityType, prefix, prefixers[i%len(prefixers)])
+		registry.MustRegister(entityType, prefix, prefixers[i%len(prefixers)])
+	}
+	frozen := builder.MustBuild()
+
+	inputs := []string{"", "p", "p_1", "q1_1", "P1_1", "p12_", "p499:x", "P498_x", "p5000_1", "p1"}
+	for i := 0; i < 600; i++ {
+		inputs = append(inputs, fmt.Sprintf("p%d_%d", i, i), fmt.Sprintf("P%d:%d", i, i))
+	}
+
+	for _, input := range inputs {
+		expectedType, expectedRaw, expectedOK := registry.MatchPrefix(input)
+		entityType, rawID, ok := frozen.MatchPrefix(input)
+		if entityType != expectedType || rawID != expectedRaw || ok != expectedOK {
+			t.Errorf("%q: expected (%s, %s, %v), got (%s, %s, %v)", input, expectedType, expectedRaw, expectedOK, entityType, rawID, ok)
+		}
+	}
+}
+
+func TestFrozenRegistry_Empty(t *testing.T) {
+	registry := prefixid.NewBuilder[string]().MustBuild()
+
+	if _, _, ok := registry.MatchPrefix("usr_1"); ok {
+		t.Error("Expected no match in an empty registry")
+	}
+
+	if _, err := registry.PrefixID("user", "1"); !errors.Is(err, prefixid.ErrUnknownEntityType) {
+		t.Errorf("Expected ErrUnknownEntityType, got %v", err)
+	}
+}
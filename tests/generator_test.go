@@ -0,0 +1,208 @@
+package prefixid_test
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jasonKoogler/prefixid"
+	"github.com/oklog/ulid/v2"
+	"github.com/segmentio/ksuid"
+)
+
+func TestUUIDGenerators(t *testing.T) {
+	testCases := []struct {
+		name      string
+		generator prefixid.Generator[uuid.UUID]
+		version   uuid.Version
+	}{
+		{"v4", prefixid.UUIDv4Generator{}, 4},
+		{"v7", prefixid.UUIDv7Generator{}, 7},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			id, err := tc.generator.Generate()
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if id.Version() != tc.version {
+				t.Errorf("Expected version %d, got %d", tc.version, id.Version())
+			}
+		})
+	}
+}
+
+func TestULIDGenerator_Monotonic(t *testing.T) {
+	generator := prefixid.NewULIDGenerator()
+
+	const numIDs = 1000
+	ids := make([]ulid.ULID, 0, numIDs)
+	for i := 0; i < numIDs; i++ {
+		id, err := generator.Generate()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		ids = append(ids, id)
+	}
+
+	for i := 1; i < len(ids); i++ {
+		if ids[i].Compare(ids[i-1]) <= 0 {
+			t.Fatalf("Expected ULIDs to be strictly increasing: %s then %s", ids[i-1], ids[i])
+		}
+	}
+}
+
+func TestULIDGenerator_ZeroValue(t *testing.T) {
+	var generator prefixid.ULIDGenerator
+
+	first, err := generator.Generate()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	second, err := generator.Generate()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if second.Compare(first) <= 0 {
+		t.Errorf("Expected ULIDs to be strictly increasing: %s then %s", first, second)
+	}
+}
+
+func TestULIDGenerator_Concurrent(t *testing.T) {
+	generator := prefixid.NewULIDGenerator()
+
+	const numOps = 100
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+	seen := make(map[ulid.ULID]bool)
+
+	wg.Add(numOps)
+	for i := 0; i < numOps; i++ {
+		go func() {
+			defer wg.Done()
+
+			id, err := generator.Generate()
+			if err != nil {
+				t.Errorf("Unexpected error: %v", err)
+				return
+			}
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			if seen[id] {
+				t.Errorf("Duplicate ULID %s", id)
+			}
+			seen[id] = true
+		}()
+	}
+
+	wg.Wait()
+}
+
+func TestKSUIDGenerator(t *testing.T) {
+	id, err := prefixid.KSUIDGenerator{}.Generate()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if id == ksuid.Nil {
+		t.Error("Expected non-nil KSUID")
+	}
+}
+
+func TestRegistry_New(t *testing.T) {
+	registry := prefixid.NewRegistry[uuid.UUID]()
+	registry.Register("order", "ord", prefixid.UUIDPrefixer{})
+
+	if err := registry.SetGenerator("order", prefixid.UUIDv7Generator{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	prefixedID, id, err := registry.New("order")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if prefixedID != "ord_"+id.String() {
+		t.Errorf("Expected 'ord_%s', got %s", id, prefixedID)
+	}
+
+	if id.Version() != 7 {
+		t.Errorf("Expected version 7 UUID, got version %d", id.Version())
+	}
+
+	parsed, err := registry.ParsePrefixedID("order", prefixedID)
+	if err != nil || parsed != id {
+		t.Errorf("Expected %s, got %s (err=%v)", id, parsed, err)
+	}
+}
+
+func TestRegistry_NewErrors(t *testing.T) {
+	registry := prefixid.NewRegistry[uuid.UUID]()
+	registry.Register("order", "ord", prefixid.UUIDPrefixer{})
+
+	if _, _, err := registry.New("order"); !errors.Is(err, prefixid.ErrNoGenerator) {
+		t.Errorf("Expected ErrNoGenerator, got %v", err)
+	}
+
+	if _, _, err := registry.New("invoice"); !errors.Is(err, prefixid.ErrUnknownEntityType) {
+		t.Errorf("Expected ErrUnknownEntityType, got %v", err)
+	}
+
+	if err := registry.SetGenerator("invoice", prefixid.UUIDv4Generator{}); !errors.Is(err, prefixid.ErrUnknownEntityType) {
+		t.Errorf("Expected ErrUnknownEntityType, got %v", err)
+	}
+
+	failure := errors.New("entropy exhausted")
+	_ = registry.SetGenerator("order", prefixid.GeneratorFunc[uuid.UUID](func() (uuid.UUID, error) {
+		return uuid.Nil, failure
+	}))
+
+	if _, _, err := registry.New("order"); !errors.Is(err, failure) {
+		t.Errorf("Expected generator error, got %v", err)
+	}
+}
+
+func TestRegistry_NewWithEntries(t *testing.T) {
+	registry := prefixid.NewRegistryWithEntries(map[string]prefixid.Entry[ulid.ULID]{
+		"session": {Prefix: "ses", Prefixer: prefixid.ULIDPrefixer{}, Generator: prefixid.NewULIDGenerator()},
+	})
+
+	prefixedID, id, err := registry.New("session")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !strings.HasPrefix(prefixedID, "ses_") || strings.TrimPrefix(prefixedID, "ses_") != id.String() {
+		t.Errorf("Unexpected prefixed ID %s for %s", prefixedID, id)
+	}
+}
+
+// generatingPrefixer is a prefixer that also generates IDs
+type generatingPrefixer struct {
+	prefixid.IntPrefixer
+}
+
+func (generatingPrefixer) Generate() (int, error) {
+	return 7, nil
+}
+
+func TestRegistry_NewFromPrefixer(t *testing.T) {
+	registry := prefixid.NewRegistry[int]()
+	registry.Register("customer", "cust", generatingPrefixer{})
+
+	prefixedID, id, err := registry.New("customer")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if prefixedID != "cust_7" || id != 7 {
+		t.Errorf("Expected (cust_7, 7), got (%s, %d)", prefixedID, id)
+	}
+}
@@ -0,0 +1,220 @@
+package prefixid_test
+
+import (
+	"encoding"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jasonKoogler/prefixid"
+)
+
+type User struct{}
+
+func (User) Prefix() string { return "usr" }
+
+type Order struct{}
+
+func (Order) Prefix() string { return "ord" }
+
+// Ticket uses a custom prefixer for its IDs
+type Ticket struct{}
+
+func (Ticket) Prefix() string { return "tkt" }
+
+func (Ticket) Prefixer() prefixid.IDPrefixer[string] { return colonPrefixer{} }
+
+type colonPrefixer struct{ prefixid.StringPrefixer }
+
+func (colonPrefixer) Attach(prefix string, id string) string {
+	return prefix + ":" + id
+}
+
+func (colonPrefixer) Detach(prefix string, prefixedID string) (string, bool) {
+	if len(prefixedID) > len(prefix) && prefixedID[:len(prefix)+1] == prefix+":" {
+		return prefixedID[len(prefix)+1:], true
+	}
+	return "", false
+}
+
+func TestID_String(t *testing.T) {
+	orderUUID := uuid.MustParse("f47ac10b-58cc-0372-8567-0e02b2c3d479")
+
+	testCases := []struct {
+		name     string
+		id       interface{ String() string }
+		expected string
+	}{
+		{"uuid", prefixid.NewID[Order](orderUUID), "ord_f47ac10b-58cc-0372-8567-0e02b2c3d479"},
+		{"int", prefixid.NewID[User](42), "usr_42"},
+		{"string", prefixid.NewID[User]("abc"), "usr_abc"},
+		{"custom prefixer", prefixid.NewID[Ticket]("abc"), "tkt:abc"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if result := tc.id.String(); result != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestParseID(t *testing.T) {
+	orderUUID := uuid.MustParse("f47ac10b-58cc-0372-8567-0e02b2c3d479")
+
+	id, err := prefixid.ParseID[Order, uuid.UUID]("ord_f47ac10b-58cc-0372-8567-0e02b2c3d479")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if id.Raw() != orderUUID {
+		t.Errorf("Expected %s, got %s", orderUUID, id.Raw())
+	}
+
+	if id != prefixid.NewID[Order](orderUUID) {
+		t.Errorf("Expected parsed ID to equal constructed ID")
+	}
+
+	// Wrong entity prefix
+	if _, err := prefixid.ParseID[User, uuid.UUID]("ord_f47ac10b-58cc-0372-8567-0e02b2c3d479"); err == nil {
+		t.Error("Expected error for wrong prefix, got nil")
+	}
+
+	// Malformed underlying ID
+	if _, err := prefixid.ParseID[Order, uuid.UUID]("ord_not-a-uuid"); err == nil {
+		t.Error("Expected error for malformed ID, got nil")
+	}
+
+	// Custom prefixer
+	ticket, err := prefixid.ParseID[Ticket, string]("tkt:abc")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if ticket.Raw() != "abc" {
+		t.Errorf("Expected abc, got %s", ticket.Raw())
+	}
+}
+
+func TestMustParseID(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic for invalid ID")
+		}
+	}()
+
+	prefixid.MustParseID[User, int]("usr_abc")
+}
+
+func TestID_IsZero(t *testing.T) {
+	var id prefixid.ID[Order, uuid.UUID]
+	if !id.IsZero() {
+		t.Error("Expected zero ID to report IsZero")
+	}
+
+	if prefixid.NewID[Order](uuid.New()).IsZero() {
+		t.Error("Expected non-zero ID not to report IsZero")
+	}
+
+	if id.Prefix() != "ord" {
+		t.Errorf("Expected prefix ord, got %s", id.Prefix())
+	}
+}
+
+type orderPayload struct {
+	ID       prefixid.ID[Order, uuid.UUID]  `json:"id"`
+	Customer *prefixid.ID[User, int]        `json:"customer,omitempty"`
+	Items    map[prefixid.ID[User, int]]int `json:"items,omitempty"`
+}
+
+func TestID_JSON(t *testing.T) {
+	orderUUID := uuid.MustParse("f47ac10b-58cc-0372-8567-0e02b2c3d479")
+	customer := prefixid.NewID[User](42)
+
+	payload := orderPayload{
+		ID:       prefixid.NewID[Order](orderUUID),
+		Customer: &customer,
+		Items:    map[prefixid.ID[User, int]]int{prefixid.NewID[User](7): 2},
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+
+	expected := `{"id":"ord_f47ac10b-58cc-0372-8567-0e02b2c3d479","customer":"usr_42","items":{"usr_7":2}}`
+	if string(data) != expected {
+		t.Errorf("Expected %s, got %s", expected, data)
+	}
+
+	var decoded orderPayload
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Failed to unmarshal: %v", err)
+	}
+
+	if decoded.ID != payload.ID || *decoded.Customer != customer || decoded.Items[prefixid.NewID[User](7)] != 2 {
+		t.Errorf("Expected %+v, got %+v", payload, decoded)
+	}
+}
+
+func TestID_JSONRejectsInvalid(t *testing.T) {
+	testCases := []struct {
+		name        string
+		data        string
+		expectedErr error
+	}{
+		{"wrong prefix", `{"id":"usr_f47ac10b-58cc-0372-8567-0e02b2c3d479"}`, prefixid.ErrPrefixMismatch},
+		{"malformed uuid", `{"id":"ord_not-a-uuid"}`, prefixid.ErrMalformedID},
+		{"empty", `{"id":""}`, prefixid.ErrPrefixMismatch},
+		{"wrong map key prefix", `{"items":{"ord_7":2}}`, prefixid.ErrPrefixMismatch},
+		{"malformed map key", `{"items":{"usr_x":2}}`, prefixid.ErrMalformedID},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var decoded orderPayload
+			err := json.Unmarshal([]byte(tc.data), &decoded)
+			if !errors.Is(err, tc.expectedErr) {
+				t.Errorf("Expected %v, got %v", tc.expectedErr, err)
+			}
+		})
+	}
+
+	var decoded orderPayload
+	if err := json.Unmarshal([]byte(`{"id":42}`), &decoded); err == nil {
+		t.Error("Expected error for non-string ID, got nil")
+	}
+}
+
+func TestID_JSONNull(t *testing.T) {
+	var decoded orderPayload
+	if err := json.Unmarshal([]byte(`{"id":null,"customer":null}`), &decoded); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !decoded.ID.IsZero() || decoded.Customer != nil {
+		t.Errorf("Expected zero values, got %+v", decoded)
+	}
+}
+
+func TestID_Text(t *testing.T) {
+	var _ encoding.TextMarshaler = prefixid.ID[User, int]{}
+	var _ encoding.TextUnmarshaler = &prefixid.ID[User, int]{}
+
+	text, err := prefixid.NewID[Ticket]("abc").MarshalText()
+	if err != nil || string(text) != "tkt:abc" {
+		t.Errorf("Expected 'tkt:abc', got %s (err=%v)", text, err)
+	}
+
+	var ticket prefixid.ID[Ticket, string]
+	if err := ticket.UnmarshalText([]byte("tkt_abc")); !errors.Is(err, prefixid.ErrPrefixMismatch) {
+		t.Errorf("Expected ErrPrefixMismatch, got %v", err)
+	}
+
+	// There is no built-in prefixer for float64
+	if _, err := prefixid.NewID[User](1.5).MarshalText(); !errors.Is(err, prefixid.ErrNoPrefixer) {
+		t.Errorf("Expected ErrNoPrefixer, got %v", err)
+	}
+}
@@ -0,0 +1,157 @@
+package prefixid_test
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/jasonKoogler/prefixid"
+)
+
+func TestIntegerPrefixer_Attach(t *testing.T) {
+	testCases := []struct {
+		name     string
+		attach   func() string
+		expected string
+	}{
+		{"int64", func() string { return prefixid.IntegerPrefixer[int64]{}.Attach("usr", 123) }, "usr_123"},
+		{"int64 min", func() string { return prefixid.IntegerPrefixer[int64]{}.Attach("usr", math.MinInt64) }, "usr_-9223372036854775808"},
+		{"uint64 max", func() string { return prefixid.IntegerPrefixer[uint64]{}.Attach("usr", math.MaxUint64) }, "usr_18446744073709551615"},
+		{"int8 min", func() string { return prefixid.IntegerPrefixer[int8]{}.Attach("usr", math.MinInt8) }, "usr_-128"},
+		{"zero", func() string { return prefixid.IntegerPrefixer[uint16]{}.Attach("usr", 0) }, "usr_0"},
+		{"base36", func() string {
+			return prefixid.IntegerPrefixer[int64]{Encoding: prefixid.IntegerBase36}.Attach("usr", 1234567)
+		}, "usr_qglj"},
+		{"base62", func() string {
+			return prefixid.IntegerPrefixer[int64]{Encoding: prefixid.IntegerBase62}.Attach("usr", 1234567)
+		}, "usr_5BAN"},
+		{"base62 negative", func() string {
+			return prefixid.IntegerPrefixer[int32]{Encoding: prefixid.IntegerBase62}.Attach("usr", -62)
+		}, "usr_-10"},
+		{"format", func() string {
+			return prefixid.NewIntegerPrefixer[uint32](prefixid.WithSeparator(":")).Attach("usr", 7)
+		}, "usr:7"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if result := tc.attach(); result != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestIntegerPrefixer_Parse(t *testing.T) {
+	prefixer := prefixid.IntegerPrefixer[int64]{}
+
+	testCases := []struct {
+		input       string
+		expected    int64
+		expectedErr error
+	}{
+		{"123", 123, nil},
+		{"0", 0, nil},
+		{"-789", -789, nil},
+		{"9223372036854775807", math.MaxInt64, nil},
+		{"-9223372036854775808", math.MinInt64, nil},
+		{"9223372036854775808", 0, strconv.ErrRange},
+		{"-9223372036854775809", 0, strconv.ErrRange},
+		{"99999999999999999999999", 0, strconv.ErrRange},
+		{"+5", 0, strconv.ErrSyntax},
+		{"007", 0, strconv.ErrSyntax},
+		{"00", 0, strconv.ErrSyntax},
+		{"-0", 0, strconv.ErrSyntax},
+		{"-", 0, strconv.ErrSyntax},
+		{"", 0, strconv.ErrSyntax},
+		{"12a", 0, strconv.ErrSyntax},
+		{" 12", 0, strconv.ErrSyntax},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			result, err := prefixer.Parse(tc.input)
+			if tc.expectedErr != nil {
+				if !errors.Is(err, tc.expectedErr) {
+					t.Errorf("Expected %v, got %v", tc.expectedErr, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if result != tc.expected {
+				t.Errorf("Expected %d, got %d", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestIntegerPrefixer_ParseRange(t *testing.T) {
+	if _, err := (prefixid.IntegerPrefixer[int8]{}).Parse("128"); !errors.Is(err, strconv.ErrRange) {
+		t.Errorf("Expected ErrRange for int8 128, got %v", err)
+	}
+
+	if v, err := (prefixid.IntegerPrefixer[int8]{}).Parse("-128"); err != nil || v != math.MinInt8 {
+		t.Errorf("Expected -128, got %d (err=%v)", v, err)
+	}
+
+	if _, err := (prefixid.IntegerPrefixer[uint8]{}).Parse("256"); !errors.Is(err, strconv.ErrRange) {
+		t.Errorf("Expected ErrRange for uint8 256, got %v", err)
+	}
+
+	if _, err := (prefixid.IntegerPrefixer[uint32]{}).Parse("-1"); !errors.Is(err, strconv.ErrSyntax) {
+		t.Errorf("Expected ErrSyntax for negative unsigned, got %v", err)
+	}
+
+	if v, err := (prefixid.IntegerPrefixer[uint64]{}).Parse("18446744073709551615"); err != nil || v != math.MaxUint64 {
+		t.Errorf("Expected max uint64, got %d (err=%v)", v, err)
+	}
+
+	if _, err := (prefixid.IntegerPrefixer[uint64]{}).Parse("18446744073709551616"); !errors.Is(err, strconv.ErrRange) {
+		t.Errorf("Expected ErrRange for uint64 overflow, got %v", err)
+	}
+}
+
+type customerID uint32
+
+func TestIntegerPrefixer_RoundTrip(t *testing.T) {
+	encodings := []prefixid.IntegerEncoding{prefixid.IntegerDecimal, prefixid.IntegerBase36, prefixid.IntegerBase62}
+	values := []int64{0, 1, -1, 61, 62, -62, 1234567, math.MaxInt64, math.MinInt64}
+
+	for _, encoding := range encodings {
+		prefixer := prefixid.IntegerPrefixer[int64]{Encoding: encoding}
+		for _, value := range values {
+			rawID, ok := prefixer.Detach("usr", prefixer.Attach("usr", value))
+			if !ok {
+				t.Fatalf("Failed to detach %d", value)
+			}
+
+			parsed, err := prefixer.Parse(rawID)
+			if err != nil || parsed != value {
+				t.Errorf("Encoding %d: expected %d, got %d (err=%v)", encoding, value, parsed, err)
+			}
+		}
+	}
+
+	// Named integer types are supported too
+	registry := prefixid.NewRegistry[customerID]()
+	registry.Register("customer", "cust", prefixid.IntegerPrefixer[customerID]{Encoding: prefixid.IntegerBase36})
+
+	prefixedID, err := registry.PrefixID("customer", 1234567)
+	if err != nil || prefixedID != "cust_qglj" {
+		t.Errorf("Expected 'cust_qglj', got %s (err=%v)", prefixedID, err)
+	}
+
+	id, err := registry.ParsePrefixedID("customer", prefixedID)
+	if err != nil || id != 1234567 {
+		t.Errorf("Expected 1234567, got %d (err=%v)", id, err)
+	}
+
+	if _, err := registry.ParsePrefixedID("customer", "cust_0qglj"); !errors.Is(err, prefixid.ErrMalformedID) {
+		t.Errorf("Expected ErrMalformedID for leading zero, got %v", err)
+	}
+}
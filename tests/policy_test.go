@@ -0,0 +1,125 @@
+package prefixid_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jasonKoogler/prefixid"
+)
+
+func TestPrefixPolicy_Validate(t *testing.T) {
+	testCases := []struct {
+		name   string
+		policy prefixid.PrefixPolicy
+		prefix string
+		valid  bool
+	}{
+		{"default valid", prefixid.DefaultPrefixPolicy, "usr", true},
+		{"default digits", prefixid.DefaultPrefixPolicy, "v2", true},
+		{"empty", prefixid.DefaultPrefixPolicy, "", false},
+		{"separator", prefixid.DefaultPrefixPolicy, "us_r", false},
+		{"uppercase", prefixid.DefaultPrefixPolicy, "USR", false},
+		{"too long", prefixid.DefaultPrefixPolicy, "abcdefghijklmnopqrstuvwxyzabcdefg", false},
+		{"min length", prefixid.PrefixPolicy{MinLength: 3}, "us", false},
+		{"max length", prefixid.PrefixPolicy{MaxLength: 3}, "user", false},
+		{"any charset", prefixid.PrefixPolicy{}, "Usr-1", true},
+		{"custom separator", prefixid.PrefixPolicy{Separator: ":"}, "us_r", true},
+		{"custom separator rejected", prefixid.PrefixPolicy{Separator: ":"}, "us:r", false},
+		{"custom charset", prefixid.PrefixPolicy{Charset: "ABC"}, "CAB", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.policy.Validate(tc.prefix)
+			if tc.valid && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+
+			if !tc.valid && !errors.Is(err, prefixid.ErrInvalidPrefix) {
+				t.Errorf("Expected ErrInvalidPrefix, got %v", err)
+			}
+		})
+	}
+}
+
+func TestTryRegister(t *testing.T) {
+	registry := prefixid.NewRegistry[string]()
+
+	if err := registry.TryRegister("user", "usr", prefixid.StringPrefixer{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	testCases := []struct {
+		name       string
+		entityType string
+		prefix     string
+		expected   error
+	}{
+		{"existing entity type", "user", "u", prefixid.ErrEntityTypeExists},
+		{"duplicate prefix", "account", "usr", prefixid.ErrDuplicatePrefix},
+		{"shorter overlapping prefix", "session", "us", prefixid.ErrPrefixOverlap},
+		{"longer overlapping prefix", "usage", "usra", prefixid.ErrPrefixOverlap},
+		{"invalid prefix", "post", "p_st", prefixid.ErrInvalidPrefix},
+		{"empty prefix", "post", "", prefixid.ErrInvalidPrefix},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := registry.TryRegister(tc.entityType, tc.prefix, prefixid.StringPrefixer{})
+			if !errors.Is(err, tc.expected) {
+				t.Errorf("Expected %v, got %v", tc.expected, err)
+			}
+		})
+	}
+
+	// The original registration must be untouched
+	prefixedID, err := registry.PrefixID("user", "123")
+	if err != nil || prefixedID != "usr_123" {
+		t.Errorf("Expected 'usr_123', got %s (err=%v)", prefixedID, err)
+	}
+
+	if types := registry.GetEntityTypes(); len(types) != 1 {
+		t.Errorf("Expected 1 entity type, got %v", types)
+	}
+
+	if err := registry.TryRegister("post", "pst", nil); !errors.Is(err, prefixid.ErrNoPrefixer) {
+		t.Errorf("Expected ErrNoPrefixer, got %v", err)
+	}
+}
+
+func TestTryRegister_AllowNested(t *testing.T) {
+	registry := prefixid.NewRegistry[string](prefixid.WithPrefixPolicy(prefixid.PrefixPolicy{
+		Charset:     prefixid.DefaultPrefixCharset,
+		AllowNested: true,
+	}))
+
+	if err := registry.TryRegister("user", "usr", prefixid.StringPrefixer{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if err := registry.TryRegister("session", "us", prefixid.StringPrefixer{}); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	if err := registry.TryRegister("account", "usr", prefixid.StringPrefixer{}); !errors.Is(err, prefixid.ErrDuplicatePrefix) {
+		t.Errorf("Expected ErrDuplicatePrefix, got %v", err)
+	}
+}
+
+func TestMustRegister(t *testing.T) {
+	registry := prefixid.NewRegistry[string]()
+	registry.MustRegister("user", "usr", prefixid.StringPrefixer{})
+
+	defer func() {
+		r := recover()
+		if r == nil {
+			t.Fatal("Expected panic on conflicting registration")
+		}
+
+		if err, ok := r.(error); !ok || !errors.Is(err, prefixid.ErrDuplicatePrefix) {
+			t.Errorf("Expected ErrDuplicatePrefix panic, got %v", r)
+		}
+	}()
+
+	registry.MustRegister("account", "usr", prefixid.StringPrefixer{})
+}
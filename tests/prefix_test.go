@@ -1,8 +1,10 @@
 package prefixid_test
 
 import (
+	"errors"
 	"testing"
 
+	"github.com/google/uuid"
 	"github.com/jasonKoogler/prefixid"
 )
 
@@ -170,3 +172,180 @@ func TestMatchPrefix(t *testing.T) {
 		t.Error("Expected not to match prefix, but did")
 	}
 }
+
+func TestMatchPrefix_LongestMatch(t *testing.T) {
+	registry := prefixid.NewRegistry[string]()
+	registry.Register("user", "usr", prefixid.StringPrefixer{})
+	registry.Register("session", "us", prefixid.StringPrefixer{})
+	registry.Register("usage", "usr_x", prefixid.StringPrefixer{})
+
+	testCases := []struct {
+		prefixedID     string
+		expectedEntity string
+		expectedID     string
+	}{
+		{"usr_123", "user", "123"},
+		{"us_123", "session", "123"},
+		{"usr_x_123", "usage", "123"},
+		{"usr_xy", "user", "xy"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.prefixedID, func(t *testing.T) {
+			// Repeat to catch any dependence on map iteration order
+			for i := 0; i < 50; i++ {
+				entityType, rawID, ok := registry.MatchPrefix(tc.prefixedID)
+				if !ok {
+					t.Fatal("Expected to match prefix, but did not")
+				}
+
+				if entityType != tc.expectedEntity || rawID != tc.expectedID {
+					t.Fatalf("Expected (%s, %s), got (%s, %s)", tc.expectedEntity, tc.expectedID, entityType, rawID)
+				}
+			}
+		})
+	}
+}
+
+func TestMatchPrefix_SharedPrefix(t *testing.T) {
+	registry := prefixid.NewRegistry[string]()
+	registry.Register("post", "p", prefixid.StringPrefixer{})
+	registry.Register("page", "p", prefixid.StringPrefixer{})
+
+	for i := 0; i < 50; i++ {
+		entityType, _, ok := registry.MatchPrefix("p_1")
+		if !ok || entityType != "page" {
+			t.Fatalf("Expected entity type 'page', got %q (ok=%v)", entityType, ok)
+		}
+	}
+}
+
+func TestMatchPrefix_AfterReregister(t *testing.T) {
+	registry := prefixid.NewRegistry[string]()
+	registry.Register("user", "usr", prefixid.StringPrefixer{})
+	registry.Register("user", "u", prefixid.StringPrefixer{})
+
+	// The old prefix must no longer be indexed
+	if _, _, ok := registry.MatchPrefix("usr_123"); ok {
+		t.Error("Expected old prefix not to match after re-registering")
+	}
+
+	entityType, rawID, ok := registry.MatchPrefix("u_123")
+	if !ok || entityType != "user" || rawID != "123" {
+		t.Errorf("Expected (user, 123), got (%s, %s, %v)", entityType, rawID, ok)
+	}
+}
+
+func TestNewRegistryWithPrefixes_CopiesMap(t *testing.T) {
+	prefixMap := map[string]string{"user": "usr"}
+
+	registry := prefixid.NewRegistryWithPrefixes[string](prefixMap)
+	prefixMap["post"] = "pst"
+	prefixMap["user"] = "u"
+
+	types := registry.GetEntityTypes()
+	if len(types) != 1 || types[0] != "user" {
+		t.Errorf("Expected ['user'], got %v", types)
+	}
+}
+
+func TestNewRegistryWithPrefixer(t *testing.T) {
+	prefixMap := map[string]string{
+		"user":     "usr",
+		"customer": "cust",
+	}
+
+	registry := prefixid.NewRegistryWithPrefixer[int](prefixMap, prefixid.IntPrefixer{})
+	prefixMap["customer"] = "c"
+
+	prefixedID, err := registry.PrefixID("customer", 42)
+	if err != nil {
+		t.Fatalf("Failed to prefix ID: %v", err)
+	}
+
+	if prefixedID != "cust_42" {
+		t.Errorf("Expected 'cust_42', got %s", prefixedID)
+	}
+
+	id, err := registry.ParsePrefixedID("user", "usr_7")
+	if err != nil || id != 7 {
+		t.Errorf("Expected 7, got %d (err=%v)", id, err)
+	}
+
+	entityType, _, ok := registry.MatchPrefix("cust_42")
+	if !ok || entityType != "customer" {
+		t.Errorf("Expected entity type 'customer', got %s (ok=%v)", entityType, ok)
+	}
+}
+
+func TestNewRegistryWithEntries(t *testing.T) {
+	registry := prefixid.NewRegistryWithEntries(map[string]prefixid.Entry[string]{
+		"user": {Prefix: "usr", Prefixer: prefixid.StringPrefixer{}},
+		"post": {Prefix: "pst", Prefixer: prefixid.NewStringPrefixer(prefixid.WithSeparator(":"))},
+	})
+
+	userID, err := registry.PrefixID("user", "123")
+	if err != nil || userID != "usr_123" {
+		t.Errorf("Expected 'usr_123', got %s (err=%v)", userID, err)
+	}
+
+	postID, err := registry.PrefixID("post", "456")
+	if err != nil || postID != "pst:456" {
+		t.Errorf("Expected 'pst:456', got %s (err=%v)", postID, err)
+	}
+
+	entityType, rawID, ok := registry.MatchPrefix("pst:456")
+	if !ok || entityType != "post" || rawID != "456" {
+		t.Errorf("Expected (post, 456), got (%s, %s, %v)", entityType, rawID, ok)
+	}
+}
+
+func TestMatchPrefix_WithoutPrefixer(t *testing.T) {
+	registry := prefixid.NewRegistryWithPrefixes[string](map[string]string{"post": "pst"})
+	registry.Register("user", "usr", prefixid.StringPrefixer{})
+
+	if _, _, ok := registry.MatchPrefix("pst_123"); ok {
+		t.Error("Expected entity type without a prefixer not to match")
+	}
+}
+
+func TestResolve(t *testing.T) {
+	registry := prefixid.NewRegistry[uuid.UUID]()
+	registry.Register("order", "ord", prefixid.UUIDPrefixer{})
+	registry.Register("invoice", "inv", prefixid.UUIDPrefixer{})
+
+	orderUUID := uuid.MustParse("f47ac10b-58cc-0372-8567-0e02b2c3d479")
+
+	entityType, id, err := registry.Resolve("ord_f47ac10b-58cc-0372-8567-0e02b2c3d479")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if entityType != "order" || id != orderUUID {
+		t.Errorf("Expected (order, %s), got (%s, %s)", orderUUID, entityType, id)
+	}
+
+	// Known prefix, malformed body
+	entityType, _, err = registry.Resolve("ord_not-a-uuid")
+	if !errors.Is(err, prefixid.ErrMalformedID) {
+		t.Errorf("Expected ErrMalformedID, got %v", err)
+	}
+
+	if errors.Is(err, prefixid.ErrUnknownPrefix) {
+		t.Error("Did not expect malformed ID to match ErrUnknownPrefix")
+	}
+
+	if entityType != "order" {
+		t.Errorf("Expected entity type 'order' for malformed ID, got %s", entityType)
+	}
+
+	var parseErr *prefixid.ParseError
+	if !errors.As(err, &parseErr) || parseErr.Prefix != "ord" || parseErr.Err == nil {
+		t.Errorf("Expected ParseError wrapping the uuid error, got %v", err)
+	}
+
+	// Unknown prefix
+	if _, _, err = registry.Resolve("usr_f47ac10b-58cc-0372-8567-0e02b2c3d479"); !errors.Is(err, prefixid.ErrUnknownPrefix) {
+		t.Errorf("Expected ErrUnknownPrefix, got %v", err)
+	}
+}
@@ -174,3 +174,42 @@ func TestIntegration_MatchPrefix(t *testing.T) {
 		})
 	}
 }
+
+func TestIntegration_Catalog(t *testing.T) {
+	// A single catalog holds entities of every ID type
+	catalog := prefixid.NewCatalog()
+	prefixid.CatalogRegister[string](catalog, "user", "usr", prefixid.StringPrefixer{})
+	prefixid.CatalogRegister[int](catalog, "product", "prd", prefixid.IntPrefixer{})
+	prefixid.CatalogRegister[uuid.UUID](catalog, "order", "ord", prefixid.UUIDPrefixer{})
+	prefixid.CatalogRegister[ulid.ULID](catalog, "session", "ses", prefixid.ULIDPrefixer{})
+	prefixid.CatalogRegister[ksuid.KSUID](catalog, "transaction", "txn", prefixid.KSUIDPrefixer{})
+
+	transactionId, _ := ksuid.Parse("0ujtsYcgvSTl8PAuAdqWYSMnLOv")
+	transactionPrefixedID, err := prefixid.CatalogPrefixID(catalog, "transaction", transactionId)
+	if err != nil {
+		t.Fatalf("Failed to prefix transaction ID: %v", err)
+	}
+
+	// The entity type can be resolved without knowing the ID type up front
+	entityType, id, err := catalog.Resolve(transactionPrefixedID)
+	if err != nil {
+		t.Fatalf("Failed to resolve transaction ID: %v", err)
+	}
+
+	if entityType != "transaction" {
+		t.Errorf("Expected entity type 'transaction', got %s", entityType)
+	}
+
+	if id != transactionId {
+		t.Errorf("Expected %s, got %v", transactionId, id)
+	}
+
+	parsedTransactionID, err := prefixid.Lookup[ksuid.KSUID](catalog, "transaction", transactionPrefixedID)
+	if err != nil {
+		t.Errorf("Failed to look up transaction ID: %v", err)
+	}
+
+	if parsedTransactionID != transactionId {
+		t.Errorf("Expected %s, got %s", transactionId, parsedTransactionID)
+	}
+}
@@ -0,0 +1,245 @@
+package prefixid_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jasonKoogler/prefixid"
+)
+
+// nestedScopes allows underscores in prefixes and scopes, so scopes can be
+// nested like "test_eu"
+var nestedScopes = prefixid.WithPrefixPolicy(prefixid.PrefixPolicy{
+	MinLength: 1,
+	Charset:   prefixid.DefaultPrefixCharset + "_",
+	Separator: "-",
+})
+
+func newScopedRegistry(opts ...prefixid.RegistryOption) *prefixid.Registry[string] {
+	opts = append([]prefixid.RegistryOption{nestedScopes, prefixid.WithScopes("test", "live", "test_eu")}, opts...)
+	registry := prefixid.NewRegistry[string](opts...)
+	registry.Register("secret_key", "sk", prefixid.StringPrefixer{})
+	return registry
+}
+
+func TestRegistry_PrefixScopedID(t *testing.T) {
+	registry := newScopedRegistry()
+
+	testCases := []struct {
+		scope       string
+		expected    string
+		expectedErr error
+	}{
+		{"test", "sk_test_abc", nil},
+		{"live", "sk_live_abc", nil},
+		{"test_eu", "sk_test_eu_abc", nil},
+		{"staging", "", prefixid.ErrUnknownScope},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.scope, func(t *testing.T) {
+			result, err := registry.PrefixScopedID("secret_key", tc.scope, "abc")
+			if tc.expectedErr != nil {
+				if !errors.Is(err, tc.expectedErr) {
+					t.Errorf("Expected %v, got %v", tc.expectedErr, err)
+				}
+				return
+			}
+
+			if err != nil || result != tc.expected {
+				t.Errorf("Expected %s, got %s (err=%v)", tc.expected, result, err)
+			}
+		})
+	}
+
+	if _, err := registry.PrefixScopedID("unknown", "test", "abc"); !errors.Is(err, prefixid.ErrUnknownEntityType) {
+		t.Errorf("Expected ErrUnknownEntityType, got %v", err)
+	}
+}
+
+func TestRegistry_ParseScopedID(t *testing.T) {
+	registry := newScopedRegistry()
+
+	testCases := []struct {
+		input         string
+		expectedScope string
+		expectedID    string
+		expectedErr   error
+	}{
+		{"sk_test_abc", "test", "abc", nil},
+		{"sk_live_abc", "live", "abc", nil},
+		{"sk_test_eu_abc", "test_eu", "abc", nil},
+		{"sk_staging_abc", "", "", prefixid.ErrPrefixMismatch},
+		{"pk_test_abc", "", "", prefixid.ErrPrefixMismatch},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.input, func(t *testing.T) {
+			scope, id, err := registry.ParseScopedID("secret_key", tc.input)
+			if tc.expectedErr != nil {
+				if !errors.Is(err, tc.expectedErr) {
+					t.Errorf("Expected %v, got %v", tc.expectedErr, err)
+				}
+				return
+			}
+
+			if err != nil || scope != tc.expectedScope || id != tc.expectedID {
+				t.Errorf("Expected %s %s, got %s %s (err=%v)", tc.expectedScope, tc.expectedID, scope, id, err)
+			}
+		})
+	}
+}
+
+func TestRegistry_ScopePolicy(t *testing.T) {
+	production := newScopedRegistry(prefixid.WithScopePolicy(prefixid.AllowScopes("live")))
+
+	if _, err := production.PrefixScopedID("secret_key", "test", "abc"); !errors.Is(err, prefixid.ErrScopeNotAllowed) {
+		t.Errorf("Expected ErrScopeNotAllowed, got %v", err)
+	}
+
+	scope, _, err := production.ParseScopedID("secret_key", "sk_test_abc")
+	if !errors.Is(err, prefixid.ErrScopeNotAllowed) || !errors.Is(err, prefixid.ErrPrefixMismatch) {
+		t.Errorf("Expected ErrScopeNotAllowed and ErrPrefixMismatch, got %v", err)
+	}
+
+	if scope != "test" {
+		t.Errorf("Expected the rejected scope to be reported, got %q", scope)
+	}
+
+	if _, id, err := production.ParseScopedID("secret_key", "sk_live_abc"); err != nil || id != "abc" {
+		t.Errorf("Expected abc, got %s (err=%v)", id, err)
+	}
+}
+
+func TestRegistry_Scopes(t *testing.T) {
+	registry := prefixid.NewRegistry[string](prefixid.WithScopes("live", "test", "live"))
+
+	scopes := registry.Scopes()
+	if len(scopes) != 2 {
+		t.Errorf("Expected 2 distinct scopes, got %v", scopes)
+	}
+
+	if _, _, err := registry.ParseScopedID("secret_key", "sk_live_abc"); !errors.Is(err, prefixid.ErrUnknownEntityType) {
+		t.Errorf("Expected ErrUnknownEntityType, got %v", err)
+	}
+}
+
+func TestRegistry_ScopedIDsUnscopedMethods(t *testing.T) {
+	registry := prefixid.NewRegistry[uuid.UUID](
+		prefixid.WithScopes("test", "live"),
+		prefixid.WithScopePolicy(prefixid.AllowScopes("live")),
+	)
+	registry.MustRegister("secret_key", "sk", prefixid.UUIDPrefixer{})
+
+	id := uuid.MustParse("f47ac10b-58cc-0372-8567-0e02b2c3d479")
+	liveID, err := registry.PrefixScopedID("secret_key", "live", id)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if parsed, err := registry.ParsePrefixedID("secret_key", liveID); err != nil || parsed != id {
+		t.Errorf("Expected %s, got %s (err=%v)", id, parsed, err)
+	}
+
+	if entityType, parsed, err := registry.Resolve(liveID); err != nil || entityType != "secret_key" || parsed != id {
+		t.Errorf("Expected (secret_key, %s), got (%s, %s, %v)", id, entityType, parsed, err)
+	}
+
+	if entityType, rawID, ok := registry.MatchPrefix(liveID); !ok || entityType != "secret_key" || rawID != id.String() {
+		t.Errorf("Expected (secret_key, %s), got (%s, %s, %v)", id, entityType, rawID, ok)
+	}
+
+	testID := "sk_test_" + id.String()
+	if _, err := registry.ParsePrefixedID("secret_key", testID); !errors.Is(err, prefixid.ErrScopeNotAllowed) || !errors.Is(err, prefixid.ErrPrefixMismatch) {
+		t.Errorf("Expected ErrScopeNotAllowed and ErrPrefixMismatch, got %v", err)
+	}
+
+	if _, _, err := registry.Resolve(testID); !errors.Is(err, prefixid.ErrScopeNotAllowed) {
+		t.Errorf("Expected ErrScopeNotAllowed, got %v", err)
+	}
+
+	if _, _, ok := registry.MatchPrefix(testID); ok {
+		t.Errorf("Expected %s not to match", testID)
+	}
+
+	if parsed, err := registry.ParsePrefixedID("secret_key", "sk_"+id.String()); err != nil || parsed != id {
+		t.Errorf("Expected %s, got %s (err=%v)", id, parsed, err)
+	}
+}
+
+func TestRegistry_UnscopedIDStartingWithScope(t *testing.T) {
+	registry := prefixid.NewRegistry[string](
+		prefixid.WithScopes("test", "live"),
+		prefixid.WithScopePolicy(prefixid.AllowScopes("live")),
+	)
+	registry.MustRegister("doc", "doc", prefixid.StringPrefixer{})
+
+	prefixedID, _ := registry.PrefixID("doc", "test_report")
+	if id, err := registry.ParsePrefixedID("doc", prefixedID); err != nil || id != "test_report" {
+		t.Errorf("Expected test_report, got %s (err=%v)", id, err)
+	}
+
+	if entityType, id, err := registry.Resolve(prefixedID); err != nil || entityType != "doc" || id != "test_report" {
+		t.Errorf("Expected (doc, test_report), got (%s, %s, %v)", entityType, id, err)
+	}
+
+	if _, rawID, ok := registry.MatchPrefix(prefixedID); !ok || rawID != "test_report" {
+		t.Errorf("Expected test_report, got %s (ok=%v)", rawID, ok)
+	}
+}
+
+func TestRegistry_ParseScopedIDAlias(t *testing.T) {
+	var aliasUses []string
+	registry := prefixid.NewRegistry[string](
+		prefixid.WithScopes("test", "live"),
+		prefixid.WithAliasHook(func(entityType, alias, prefixedID string) {
+			aliasUses = append(aliasUses, alias)
+		}),
+	)
+	registry.MustRegister("key", "sk", prefixid.StringPrefixer{})
+	if err := registry.RegisterAlias("key", "skey"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if scope, id, err := registry.ParseScopedID("key", "skey_live_abc"); err != nil || scope != "live" || id != "abc" {
+		t.Errorf("Expected (live, abc), got (%s, %s, %v)", scope, id, err)
+	}
+
+	if scope, id, err := registry.ParseScopedID("key", "sk_test_abc"); err != nil || scope != "test" || id != "abc" {
+		t.Errorf("Expected (test, abc), got (%s, %s, %v)", scope, id, err)
+	}
+
+	if len(aliasUses) != 1 || aliasUses[0] != "skey" {
+		t.Errorf("Expected the alias hook to be called once with skey, got %v", aliasUses)
+	}
+}
+
+func TestWithScopes_Invalid(t *testing.T) {
+	for _, scope := range []string{"", "test_eu", "Live", "test-eu"} {
+		t.Run(scope, func(t *testing.T) {
+			registry := prefixid.NewRegistry[string](prefixid.WithScopes("live", scope))
+
+			if err := registry.TryRegister("secret_key", "sk", prefixid.StringPrefixer{}); !errors.Is(err, prefixid.ErrInvalidPrefix) {
+				t.Errorf("Expected TryRegister to fail with ErrInvalidPrefix, got %v", err)
+			}
+
+			err := registry.Apply(prefixid.Change[string]{EntityType: "secret_key", Entry: prefixid.Entry[string]{Prefix: "sk", Prefixer: prefixid.StringPrefixer{}}})
+			if !errors.Is(err, prefixid.ErrInvalidPrefix) {
+				t.Errorf("Expected Apply to fail with ErrInvalidPrefix, got %v", err)
+			}
+
+			_, err = prefixid.NewBuilder[string](prefixid.WithScopes(scope)).
+				Register("secret_key", "sk", prefixid.StringPrefixer{}).
+				Build()
+			if !errors.Is(err, prefixid.ErrInvalidPrefix) {
+				t.Errorf("Expected Build to fail with ErrInvalidPrefix, got %v", err)
+			}
+		})
+	}
+
+	registry := prefixid.NewRegistry[string](nestedScopes, prefixid.WithScopes("test_eu"))
+	if err := registry.TryRegister("secret_key", "sk", prefixid.StringPrefixer{}); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
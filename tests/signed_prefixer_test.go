@@ -0,0 +1,161 @@
+package prefixid_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/jasonKoogler/prefixid"
+)
+
+var (
+	signingKey = []byte("0123456789abcdef0123456789abcdef")
+	rotatedKey = []byte("fedcba9876543210fedcba9876543210")
+)
+
+func newTestKeyring(t testing.TB) *prefixid.Keyring {
+	t.Helper()
+
+	keyring, err := prefixid.NewKeyring('k', signingKey)
+	if err != nil {
+		t.Fatalf("Failed to create keyring: %v", err)
+	}
+	return keyring
+}
+
+func TestSignedPrefixer_Attach(t *testing.T) {
+	prefixer := prefixid.NewSignedPrefixer[int](prefixid.IntPrefixer{}, newTestKeyring(t))
+
+	expected := "usr_42k1B1UriiIB8yE4WCTK"
+	if result := prefixer.Attach("usr", 42); result != expected {
+		t.Errorf("Expected %s, got %s", expected, result)
+	}
+
+	prefixer.TagSize = 32
+	if result := prefixer.Attach("usr", 42); len(result) != len("usr_42k")+43 {
+		t.Errorf("Expected a 43 character tag, got %s", result)
+	}
+}
+
+func TestSignedPrefixer_Registry(t *testing.T) {
+	registry := prefixid.NewRegistry[int]()
+	registry.Register("share", "shr", prefixid.NewSignedPrefixer[int](prefixid.IntPrefixer{}, newTestKeyring(t)))
+	registry.Register("user", "usr", prefixid.NewSignedPrefixer[int](prefixid.IntPrefixer{}, newTestKeyring(t)))
+
+	prefixedID, err := registry.PrefixID("share", 42)
+	if err != nil {
+		t.Fatalf("Failed to prefix ID: %v", err)
+	}
+
+	id, err := registry.ParsePrefixedID("share", prefixedID)
+	if err != nil || id != 42 {
+		t.Errorf("Expected 42, got %d (err=%v)", id, err)
+	}
+
+	userID, _ := registry.PrefixID("user", 42)
+
+	entityType, rawID, ok := registry.MatchPrefix(prefixedID)
+	if !ok || entityType != "share" || rawID != "42" {
+		t.Errorf("Expected share 42, got %s %s (ok=%v)", entityType, rawID, ok)
+	}
+
+	testCases := []struct {
+		name        string
+		input       string
+		expectedErr error
+	}{
+		{"guessed ID", "shr_43" + prefixedID[len("shr_42"):], prefixid.ErrInvalidSignature},
+		{"tampered tag", prefixedID[:len(prefixedID)-1] + "x", prefixid.ErrInvalidSignature},
+		{"unknown key", "shr_42z" + prefixedID[len("shr_42k"):], prefixid.ErrInvalidSignature},
+		{"unsigned", "shr_42", prefixid.ErrInvalidSignature},
+		{"other entity type", userID, prefixid.ErrPrefixMismatch},
+		{"other prefix, unsigned", "ord_42", prefixid.ErrPrefixMismatch},
+		{"other prefix, bad signature", "ord_43" + prefixedID[len("shr_42"):], prefixid.ErrPrefixMismatch},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := registry.ParsePrefixedID("share", tc.input)
+			if !errors.Is(err, tc.expectedErr) {
+				t.Errorf("Expected %v, got %v", tc.expectedErr, err)
+			}
+
+			if tc.expectedErr == prefixid.ErrPrefixMismatch && errors.Is(err, prefixid.ErrMalformedID) {
+				t.Errorf("Expected only ErrPrefixMismatch, got %v", err)
+			}
+
+			if _, _, ok := registry.MatchPrefix(tc.input); ok && tc.expectedErr == prefixid.ErrInvalidSignature {
+				t.Errorf("Expected %s not to match", tc.input)
+			}
+		})
+	}
+}
+
+func TestSignedPrefixer_KeyRotation(t *testing.T) {
+	keyring := newTestKeyring(t)
+	prefixer := prefixid.NewSignedPrefixer[int](prefixid.IntPrefixer{}, keyring)
+
+	oldID := prefixer.Attach("usr", 42)
+
+	if err := keyring.Add('n', rotatedKey); err != nil {
+		t.Fatalf("Failed to add key: %v", err)
+	}
+
+	if err := keyring.Use('n'); err != nil {
+		t.Fatalf("Failed to use key: %v", err)
+	}
+
+	newID := prefixer.Attach("usr", 42)
+	if newID == oldID || newID[len("usr_42")] != 'n' {
+		t.Errorf("Expected ID signed with key n, got %s", newID)
+	}
+
+	for _, prefixedID := range []string{oldID, newID} {
+		if rawID, ok := prefixer.Detach("usr", prefixedID); !ok || rawID != "42" {
+			t.Errorf("Expected %s to verify, got %s (ok=%v)", prefixedID, rawID, ok)
+		}
+	}
+
+	if err := keyring.Remove('n'); !errors.Is(err, prefixid.ErrInvalidKey) {
+		t.Errorf("Expected ErrInvalidKey removing the current key, got %v", err)
+	}
+
+	if err := keyring.Remove('k'); err != nil {
+		t.Fatalf("Failed to remove key: %v", err)
+	}
+
+	if err := prefixer.Verify("usr", oldID); !errors.Is(err, prefixid.ErrInvalidSignature) {
+		t.Errorf("Expected ErrInvalidSignature for a removed key, got %v", err)
+	}
+
+	if err := prefixer.Verify("usr", newID); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestKeyring_Invalid(t *testing.T) {
+	testCases := []struct {
+		name  string
+		keyID byte
+		key   []byte
+	}{
+		{"short key", 'k', []byte("short")},
+		{"invalid key ID", '_', signingKey},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := prefixid.NewKeyring(tc.keyID, tc.key); !errors.Is(err, prefixid.ErrInvalidKey) {
+				t.Errorf("Expected ErrInvalidKey, got %v", err)
+			}
+		})
+	}
+
+	keyring := newTestKeyring(t)
+	if err := keyring.Add('k', rotatedKey); !errors.Is(err, prefixid.ErrInvalidKey) {
+		t.Errorf("Expected ErrInvalidKey for a duplicate key ID, got %v", err)
+	}
+
+	if err := keyring.Use('x'); !errors.Is(err, prefixid.ErrInvalidKey) {
+		t.Errorf("Expected ErrInvalidKey for an unknown key ID, got %v", err)
+	}
+}
@@ -0,0 +1,151 @@
+package prefixid_test
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/jasonKoogler/prefixid"
+)
+
+func mustSqidsPrefixer[T prefixid.Integer](t *testing.T, config prefixid.SqidsConfig) prefixid.SqidsPrefixer[T] {
+	t.Helper()
+
+	prefixer, err := prefixid.NewSqidsPrefixer[T](config)
+	if err != nil {
+		t.Fatalf("Failed to create prefixer: %v", err)
+	}
+	return prefixer
+}
+
+func TestSqidsPrefixer_Attach(t *testing.T) {
+	testCases := []struct {
+		name     string
+		config   prefixid.SqidsConfig
+		id       uint64
+		expected string
+	}{
+		{"zero", prefixid.SqidsConfig{}, 0, "cust_bM"},
+		{"small", prefixid.SqidsConfig{}, 42, "cust_Jg"},
+		{"large", prefixid.SqidsConfig{}, 1000, "cust_pnd"},
+		{"max", prefixid.SqidsConfig{}, math.MaxUint64, "cust_eIkvoXH40Lmd"},
+		{"min length", prefixid.SqidsConfig{MinLength: 10}, 42, "cust_JgaEBgznCp"},
+		{"alphabet", prefixid.SqidsConfig{Alphabet: "0123456789abcdef"}, 1234567, "cust_1af021b"},
+		{"salt", prefixid.SqidsConfig{Salt: "customers"}, 42, "cust_5f"},
+		{"blocklist", prefixid.SqidsConfig{Blocklist: []string{"PND"}}, 1000, "cust_LM7"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			prefixer := mustSqidsPrefixer[uint64](t, tc.config)
+			if result := prefixer.Attach("cust", tc.id); result != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, result)
+			}
+		})
+	}
+
+	// The zero value uses the default config
+	if result := (prefixid.SqidsPrefixer[int]{}).Attach("cust", 42); result != "cust_Jg" {
+		t.Errorf("Expected cust_Jg, got %s", result)
+	}
+}
+
+func TestSqidsPrefixer_RoundTrip(t *testing.T) {
+	configs := []prefixid.SqidsConfig{
+		{},
+		{MinLength: 8},
+		{Salt: "orders", MinLength: 4},
+		{Alphabet: "abcdefghijk"},
+	}
+
+	for _, config := range configs {
+		prefixer := mustSqidsPrefixer[int64](t, config)
+		for _, id := range []int64{0, 1, 42, 1000, 1 << 40, math.MaxInt64, -1, math.MinInt64} {
+			rawID, ok := prefixer.Detach("ord", prefixer.Attach("ord", id))
+			if !ok {
+				t.Fatalf("Failed to detach %d", id)
+			}
+
+			parsed, err := prefixer.Parse(rawID)
+			if err != nil || parsed != id {
+				t.Errorf("Config %+v: expected %d, got %d (err=%v)", config, id, parsed, err)
+			}
+		}
+	}
+}
+
+func TestSqidsPrefixer_Parse(t *testing.T) {
+	prefixer := mustSqidsPrefixer[uint64](t, prefixid.SqidsConfig{})
+
+	testCases := []struct {
+		name        string
+		input       string
+		expectedErr error
+	}{
+		{"empty", "", strconv.ErrSyntax},
+		{"invalid character", "J-g", strconv.ErrSyntax},
+		{"several numbers", "86Rf07", strconv.ErrSyntax},
+		{"padded", "JgaEBgznCp", strconv.ErrSyntax},
+		{"wrong prefix character", "bg", strconv.ErrSyntax},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := prefixer.Parse(tc.input); !errors.Is(err, tc.expectedErr) {
+				t.Errorf("Expected %v, got %v", tc.expectedErr, err)
+			}
+		})
+	}
+
+	small := mustSqidsPrefixer[int8](t, prefixid.SqidsConfig{})
+	if _, err := small.Parse("pnd"); !errors.Is(err, strconv.ErrRange) {
+		t.Errorf("Expected ErrRange for 1000 as int8, got %v", err)
+	}
+}
+
+func TestSqidsPrefixer_Registry(t *testing.T) {
+	prefixer := mustSqidsPrefixer[int](t, prefixid.SqidsConfig{Salt: "customers", MinLength: 6})
+
+	registry := prefixid.NewRegistry[int]()
+	registry.Register("customer", "cust", prefixer)
+
+	prefixedID, err := registry.PrefixID("customer", 42)
+	if err != nil {
+		t.Fatalf("Failed to prefix ID: %v", err)
+	}
+
+	if prefixedID == "cust_42" || len(prefixedID) != len("cust_")+6 {
+		t.Errorf("Expected a 6 character Sqid, got %s", prefixedID)
+	}
+
+	id, err := registry.ParsePrefixedID("customer", prefixedID)
+	if err != nil || id != 42 {
+		t.Errorf("Expected 42, got %d (err=%v)", id, err)
+	}
+
+	if _, err := registry.ParsePrefixedID("customer", "cust_Jg"); !errors.Is(err, prefixid.ErrMalformedID) {
+		t.Errorf("Expected ErrMalformedID for an ID from another config, got %v", err)
+	}
+}
+
+func TestNewSqidsPrefixer_InvalidConfig(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config prefixid.SqidsConfig
+	}{
+		{"short alphabet", prefixid.SqidsConfig{Alphabet: "ab"}},
+		{"repeated character", prefixid.SqidsConfig{Alphabet: "abca"}},
+		{"non-ASCII alphabet", prefixid.SqidsConfig{Alphabet: "abcé"}},
+		{"negative min length", prefixid.SqidsConfig{MinLength: -1}},
+		{"long min length", prefixid.SqidsConfig{MinLength: prefixid.MaxSqidsMinLength + 1}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := prefixid.NewSqidsPrefixer[int](tc.config); !errors.Is(err, prefixid.ErrInvalidSqidsConfig) {
+				t.Errorf("Expected ErrInvalidSqidsConfig, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,278 @@
+package prefixid_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jasonKoogler/prefixid"
+	"github.com/oklog/ulid/v2"
+	"github.com/segmentio/ksuid"
+)
+
+// echoDriver is a fake database/sql driver whose queries return a single
+// row holding the query arguments, as converted by database/sql
+type echoDriver struct{}
+
+func (echoDriver) Open(name string) (driver.Conn, error) { return echoConn{}, nil }
+
+type echoConn struct{}
+
+func (echoConn) Prepare(query string) (driver.Stmt, error) {
+	return echoStmt{numInput: strings.Count(query, "?")}, nil
+}
+
+func (echoConn) Close() error { return nil }
+
+func (echoConn) Begin() (driver.Tx, error) { return nil, errors.New("transactions not supported") }
+
+type echoStmt struct{ numInput int }
+
+func (echoStmt) Close() error { return nil }
+
+func (s echoStmt) NumInput() int { return s.numInput }
+
+func (echoStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return driver.RowsAffected(1), nil
+}
+
+func (echoStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return &echoRows{values: args}, nil
+}
+
+type echoRows struct {
+	values []driver.Value
+	done   bool
+}
+
+func (r *echoRows) Columns() []string {
+	columns := make([]string, len(r.values))
+	for i := range columns {
+		columns[i] = fmt.Sprintf("c%d", i)
+	}
+	return columns
+}
+
+func (r *echoRows) Close() error { return nil }
+
+func (r *echoRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	copy(dest, r.values)
+	r.done = true
+	return nil
+}
+
+func init() {
+	sql.Register("prefixid-echo", echoDriver{})
+}
+
+func openEchoDB(t *testing.T) *sql.DB {
+	db, err := sql.Open("prefixid-echo", "")
+	if err != nil {
+		t.Fatalf("Failed to open fake database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// RawOrder stores the raw UUID
+type RawOrder struct{}
+
+func (RawOrder) Prefix() string { return "ord" }
+
+func (RawOrder) Storage() prefixid.StorageMode { return prefixid.StorageRaw }
+
+// BinaryOrder stores the UUID bytes
+type BinaryOrder struct{}
+
+func (BinaryOrder) Prefix() string { return "ord" }
+
+func (BinaryOrder) Storage() prefixid.StorageMode { return prefixid.StorageBinary }
+
+// RawCustomer stores the raw integer
+type RawCustomer struct{}
+
+func (RawCustomer) Prefix() string { return "cust" }
+
+func (RawCustomer) Storage() prefixid.StorageMode { return prefixid.StorageRaw }
+
+// BinarySession stores the ULID bytes
+type BinarySession struct{}
+
+func (BinarySession) Prefix() string { return "ses" }
+
+func (BinarySession) Storage() prefixid.StorageMode { return prefixid.StorageBinary }
+
+// BinaryTransaction stores the KSUID bytes
+type BinaryTransaction struct{}
+
+func (BinaryTransaction) Prefix() string { return "txn" }
+
+func (BinaryTransaction) Storage() prefixid.StorageMode { return prefixid.StorageBinary }
+
+// roundTrip sends an ID through the fake driver, returning the stored value
+// and scanning it back into dest
+func roundTrip(t *testing.T, db *sql.DB, id any, dest any) any {
+	t.Helper()
+
+	var stored any
+	if err := db.QueryRow("SELECT ?, ?", id, id).Scan(&stored, dest); err != nil {
+		t.Fatalf("Round trip failed: %v", err)
+	}
+	return stored
+}
+
+func TestID_SQLRoundTrip(t *testing.T) {
+	db := openEchoDB(t)
+
+	orderUUID := uuid.MustParse("f47ac10b-58cc-0372-8567-0e02b2c3d479")
+	sessionULID := ulid.MustParse("01F8MECHZX3TBDSZ9PT3RV4ZMH")
+	transactionKSUID := ksuid.New()
+
+	t.Run("prefixed", func(t *testing.T) {
+		id := prefixid.NewID[Order](orderUUID)
+
+		var scanned prefixid.ID[Order, uuid.UUID]
+		stored := roundTrip(t, db, id, &scanned)
+
+		if stored != "ord_f47ac10b-58cc-0372-8567-0e02b2c3d479" {
+			t.Errorf("Expected prefixed string to be stored, got %#v", stored)
+		}
+
+		if scanned != id {
+			t.Errorf("Expected %s, got %s", id, scanned)
+		}
+	})
+
+	t.Run("raw uuid", func(t *testing.T) {
+		id := prefixid.NewID[RawOrder](orderUUID)
+
+		var scanned prefixid.ID[RawOrder, uuid.UUID]
+		stored := roundTrip(t, db, id, &scanned)
+
+		if stored != "f47ac10b-58cc-0372-8567-0e02b2c3d479" {
+			t.Errorf("Expected raw UUID string to be stored, got %#v", stored)
+		}
+
+		if scanned != id || scanned.String() != "ord_f47ac10b-58cc-0372-8567-0e02b2c3d479" {
+			t.Errorf("Expected %s, got %s", id, scanned)
+		}
+	})
+
+	t.Run("binary uuid", func(t *testing.T) {
+		id := prefixid.NewID[BinaryOrder](orderUUID)
+
+		var scanned prefixid.ID[BinaryOrder, uuid.UUID]
+		stored := roundTrip(t, db, id, &scanned)
+
+		if b, ok := stored.([]byte); !ok || string(b) != string(orderUUID[:]) {
+			t.Errorf("Expected UUID bytes to be stored, got %#v", stored)
+		}
+
+		if scanned != id {
+			t.Errorf("Expected %s, got %s", id, scanned)
+		}
+	})
+
+	t.Run("raw int", func(t *testing.T) {
+		id := prefixid.NewID[RawCustomer](42)
+
+		var scanned prefixid.ID[RawCustomer, int]
+		stored := roundTrip(t, db, id, &scanned)
+
+		if stored != int64(42) {
+			t.Errorf("Expected int64 to be stored, got %#v", stored)
+		}
+
+		if scanned.String() != "cust_42" {
+			t.Errorf("Expected cust_42, got %s", scanned)
+		}
+	})
+
+	t.Run("binary ulid", func(t *testing.T) {
+		id := prefixid.NewID[BinarySession](sessionULID)
+
+		var scanned prefixid.ID[BinarySession, ulid.ULID]
+		stored := roundTrip(t, db, id, &scanned)
+
+		if b, ok := stored.([]byte); !ok || len(b) != 16 {
+			t.Errorf("Expected ULID bytes to be stored, got %#v", stored)
+		}
+
+		if scanned != id {
+			t.Errorf("Expected %s, got %s", id, scanned)
+		}
+	})
+
+	t.Run("binary ksuid", func(t *testing.T) {
+		id := prefixid.NewID[BinaryTransaction](transactionKSUID)
+
+		var scanned prefixid.ID[BinaryTransaction, ksuid.KSUID]
+		stored := roundTrip(t, db, id, &scanned)
+
+		if b, ok := stored.([]byte); !ok || len(b) != 20 {
+			t.Errorf("Expected KSUID bytes to be stored, got %#v", stored)
+		}
+
+		if scanned != id {
+			t.Errorf("Expected %s, got %s", id, scanned)
+		}
+	})
+}
+
+func TestID_Scan(t *testing.T) {
+	testCases := []struct {
+		name  string
+		src   any
+		valid bool
+	}{
+		{"prefixed bytes", []byte("ord_f47ac10b-58cc-0372-8567-0e02b2c3d479"), true},
+		{"wrong prefix", "usr_f47ac10b-58cc-0372-8567-0e02b2c3d479", false},
+		{"raw uuid", "f47ac10b-58cc-0372-8567-0e02b2c3d479", false},
+		{"integer", int64(42), false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var id prefixid.ID[Order, uuid.UUID]
+			err := id.Scan(tc.src)
+			if tc.valid && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+
+			if !tc.valid && err == nil {
+				t.Error("Expected error, got nil")
+			}
+		})
+	}
+}
+
+func TestID_ScanNull(t *testing.T) {
+	id := prefixid.NewID[RawCustomer](42)
+	if err := id.Scan(nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !id.IsZero() {
+		t.Errorf("Expected zero ID after scanning NULL, got %s", id)
+	}
+}
+
+func TestID_ScanRawInt(t *testing.T) {
+	var id prefixid.ID[RawCustomer, int8]
+
+	if err := id.Scan(int64(300)); err == nil {
+		t.Error("Expected overflow error, got nil")
+	}
+
+	if err := id.Scan([]byte("12")); err != nil || id.Raw() != 12 {
+		t.Errorf("Expected 12, got %d (err=%v)", id.Raw(), err)
+	}
+}
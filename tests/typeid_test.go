@@ -0,0 +1,192 @@
+package prefixid_test
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jasonKoogler/prefixid"
+)
+
+// typeIDVector is a test vector from the TypeID specification's valid.yml
+// and invalid.yml, vendored in testdata/typeid as JSON
+type typeIDVector struct {
+	Name        string `json:"name"`
+	TypeID      string `json:"typeid"`
+	Prefix      string `json:"prefix"`
+	UUID        string `json:"uuid"`
+	Description string `json:"description"`
+}
+
+func loadTypeIDVectors(t *testing.T, name string) []typeIDVector {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("testdata", "typeid", name))
+	if err != nil {
+		t.Fatalf("Failed to read test vectors: %v", err)
+	}
+
+	var vectors []typeIDVector
+	if err := json.Unmarshal(data, &vectors); err != nil {
+		t.Fatalf("Failed to decode test vectors: %v", err)
+	}
+	return vectors
+}
+
+func TestTypeID_ValidVectors(t *testing.T) {
+	prefixer := prefixid.TypeIDPrefixer{}
+
+	for _, tc := range loadTypeIDVectors(t, "valid.json") {
+		t.Run(tc.Name, func(t *testing.T) {
+			expectedUUID := uuid.MustParse(tc.UUID)
+
+			// Encoding
+			if result := prefixer.Attach(tc.Prefix, expectedUUID); result != tc.TypeID {
+				t.Errorf("Expected %s, got %s", tc.TypeID, result)
+			}
+
+			// Decoding
+			prefix, id, err := prefixid.ParseTypeID(tc.TypeID)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if prefix != tc.Prefix || id != expectedUUID {
+				t.Errorf("Expected (%q, %s), got (%q, %s)", tc.Prefix, expectedUUID, prefix, id)
+			}
+
+			// Decoding through the prefixer
+			suffix, ok := prefixer.Detach(tc.Prefix, tc.TypeID)
+			if !ok {
+				t.Fatal("Failed to detach prefix")
+			}
+
+			parsed, err := prefixer.Parse(suffix)
+			if err != nil || parsed != expectedUUID {
+				t.Errorf("Expected %s, got %s (err=%v)", expectedUUID, parsed, err)
+			}
+		})
+	}
+}
+
+func TestTypeID_InvalidVectors(t *testing.T) {
+	for _, tc := range loadTypeIDVectors(t, "invalid.json") {
+		t.Run(tc.Name, func(t *testing.T) {
+			if err := prefixid.ValidateTypeID(tc.TypeID); !errors.Is(err, prefixid.ErrInvalidTypeID) {
+				t.Errorf("Expected ErrInvalidTypeID (%s), got %v", tc.Description, err)
+			}
+		})
+	}
+}
+
+func TestValidateTypeIDPrefix(t *testing.T) {
+	testCases := []struct {
+		prefix string
+		valid  bool
+	}{
+		{"", true},
+		{"user", true},
+		{"api_key", true},
+		{"abcdefghijklmnopqrstuvwxyzabcdefghijklmnopqrstuvwxyzabcdefghijk", true},
+		{"abcdefghijklmnopqrstuvwxyzabcdefghijklmnopqrstuvwxyzabcdefghijkl", false},
+		{"User", false},
+		{"user1", false},
+		{"_user", false},
+		{"user_", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.prefix, func(t *testing.T) {
+			err := prefixid.ValidateTypeIDPrefix(tc.prefix)
+			if tc.valid && err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+
+			if !tc.valid && !errors.Is(err, prefixid.ErrInvalidTypeID) {
+				t.Errorf("Expected ErrInvalidTypeID, got %v", err)
+			}
+		})
+	}
+}
+
+func TestTypeIDPrefixer_Registry(t *testing.T) {
+	registry := prefixid.NewRegistry[uuid.UUID]()
+	registry.Register("user", "user", prefixid.TypeIDPrefixer{})
+	registry.Register("api_key", "api_key", prefixid.TypeIDPrefixer{})
+	registry.SetGenerator("user", prefixid.UUIDv7Generator{})
+
+	prefixedID, id, err := registry.New("user")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if err := prefixid.ValidateTypeID(prefixedID); err != nil {
+		t.Errorf("Expected valid TypeID, got %v", err)
+	}
+
+	entityType, resolved, err := registry.Resolve(prefixedID)
+	if err != nil || entityType != "user" || resolved != id {
+		t.Errorf("Expected (user, %s), got (%s, %s, %v)", id, entityType, resolved, err)
+	}
+
+	entityType, _, err = registry.Resolve("api_key_01h455vb4pex5vsknk084sn02q")
+	if err != nil || entityType != "api_key" {
+		t.Errorf("Expected api_key, got %s (err=%v)", entityType, err)
+	}
+
+	// An api_key TypeID is not a user TypeID
+	if _, err := registry.ParsePrefixedID("user", "api_key_01h455vb4pex5vsknk084sn02q"); !errors.Is(err, prefixid.ErrPrefixMismatch) {
+		t.Errorf("Expected ErrPrefixMismatch, got %v", err)
+	}
+}
+
+func TestTypeIDPrefixPolicy(t *testing.T) {
+	testCases := []struct {
+		name   string
+		prefix string
+		valid  bool
+	}{
+		{"lowercase", "user", true},
+		{"underscore", "api_key", true},
+		{"digit", "usr1", false},
+		{"uppercase", "User", false},
+		{"leading underscore", "_user", false},
+		{"trailing underscore", "user_", false},
+		{"too long", strings.Repeat("a", prefixid.MaxTypeIDPrefixLength+1), false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			registry := prefixid.NewRegistry[uuid.UUID](prefixid.WithPrefixPolicy(prefixid.TypeIDPrefixPolicy))
+			err := registry.TryRegister("entity", tc.prefix, prefixid.TypeIDPrefixer{})
+			if tc.valid && err != nil {
+				t.Errorf("Expected %q to be valid, got %v", tc.prefix, err)
+			}
+			if !tc.valid && !errors.Is(err, prefixid.ErrInvalidPrefix) {
+				t.Errorf("Expected ErrInvalidPrefix for %q, got %v", tc.prefix, err)
+			}
+		})
+	}
+
+	registry := prefixid.NewRegistry[uuid.UUID](prefixid.WithPrefixPolicy(prefixid.TypeIDPrefixPolicy))
+	registry.MustRegister("api", "api", prefixid.TypeIDPrefixer{})
+	registry.MustRegister("api_key", "api_key", prefixid.TypeIDPrefixer{})
+
+	entityType, _, err := registry.Resolve("api_key_01h455vb4pex5vsknk084sn02q")
+	if err != nil || entityType != "api_key" {
+		t.Errorf("Expected api_key, got %s (err=%v)", entityType, err)
+	}
+}
+
+func TestTypeIDPrefixer_DetachInvalidPrefix(t *testing.T) {
+	prefixer := prefixid.TypeIDPrefixer{}
+	prefixedID := prefixer.Attach("usr1", uuid.MustParse("01890a5d-ac96-774b-bcce-b302099a8057"))
+
+	if rawID, ok := prefixer.Detach("usr1", prefixedID); ok {
+		t.Errorf("Expected %s not to detach, got %s", prefixedID, rawID)
+	}
+}
@@ -103,3 +103,118 @@ func TestUUIDPrefixer_Parse(t *testing.T) {
 		})
 	}
 }
+
+func TestUUIDPrefixer_Encodings(t *testing.T) {
+	id := uuid.MustParse("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+
+	testCases := []struct {
+		encoding prefixid.UUIDEncoding
+		expected string
+	}{
+		{prefixid.UUIDCanonical, "ord_6ba7b810-9dad-11d1-80b4-00c04fd430c8"},
+		{prefixid.UUIDBase58, "ord_EJ34kCVxxF9jHMKD4EgrAK"},
+		{prefixid.UUIDBase62, "ord_3H8pGALtipnCnHud4zBiky"},
+		{prefixid.UUIDBase32, "ord_3BMYW117DD278R1D00R17X8C68"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expected, func(t *testing.T) {
+			prefixer := prefixid.UUIDPrefixer{Encoding: tc.encoding}
+
+			result := prefixer.Attach("ord", id)
+			if result != tc.expected {
+				t.Errorf("Expected %s, got %s", tc.expected, result)
+			}
+
+			rawID, ok := prefixer.Detach("ord", result)
+			if !ok {
+				t.Fatal("Failed to detach prefix")
+			}
+
+			parsed, err := prefixer.Parse(rawID)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if parsed != id {
+				t.Errorf("Expected %s, got %s", id, parsed)
+			}
+		})
+	}
+}
+
+func TestUUIDPrefixer_EncodingBounds(t *testing.T) {
+	ids := []uuid.UUID{
+		uuid.Nil,
+		uuid.Max,
+		uuid.MustParse("00000000-0000-0000-0000-000000000001"),
+		uuid.MustParse("80000000-0000-0000-0000-000000000000"),
+	}
+
+	for _, encoding := range []prefixid.UUIDEncoding{prefixid.UUIDBase58, prefixid.UUIDBase62, prefixid.UUIDBase32} {
+		prefixer := prefixid.UUIDPrefixer{Encoding: encoding}
+		for _, id := range ids {
+			rawID, _ := prefixer.Detach("ord", prefixer.Attach("ord", id))
+			parsed, err := prefixer.Parse(rawID)
+			if err != nil || parsed != id {
+				t.Errorf("Encoding %d: expected %s, got %s (err=%v)", encoding, id, parsed, err)
+			}
+		}
+	}
+}
+
+func TestUUIDPrefixer_EncodingSortable(t *testing.T) {
+	prefixer := prefixid.UUIDPrefixer{Encoding: prefixid.UUIDBase62}
+
+	previous := ""
+	for i := 0; i < 100; i++ {
+		id, err := uuid.NewV7()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		encoded := prefixer.Attach("ord", id)
+		if encoded <= previous {
+			t.Fatalf("Expected encoded UUIDv7s to sort: %s then %s", previous, encoded)
+		}
+		previous = encoded
+	}
+}
+
+func TestUUIDPrefixer_EncodingParse(t *testing.T) {
+	id := uuid.MustParse("6ba7b810-9dad-11d1-80b4-00c04fd430c8")
+
+	testCases := []struct {
+		name     string
+		prefixer prefixid.UUIDPrefixer
+		input    string
+		valid    bool
+	}{
+		{"canonical rejected", prefixid.UUIDPrefixer{Encoding: prefixid.UUIDBase58}, id.String(), false},
+		{"canonical accepted", prefixid.UUIDPrefixer{Encoding: prefixid.UUIDBase58, AcceptCanonical: true}, id.String(), true},
+		{"compact with canonical accepted", prefixid.UUIDPrefixer{Encoding: prefixid.UUIDBase58, AcceptCanonical: true}, "EJ34kCVxxF9jHMKD4EgrAK", true},
+		{"invalid base58 character", prefixid.UUIDPrefixer{Encoding: prefixid.UUIDBase58}, "EJ34kCVxxF9jHMKD4Egr0K", false},
+		{"too short", prefixid.UUIDPrefixer{Encoding: prefixid.UUIDBase62}, "3H8pGALtipnCnHud4zBik", false},
+		{"base62 overflow", prefixid.UUIDPrefixer{Encoding: prefixid.UUIDBase62}, "zzzzzzzzzzzzzzzzzzzzzz", false},
+		{"base32 overflow", prefixid.UUIDPrefixer{Encoding: prefixid.UUIDBase32}, "8ZZZZZZZZZZZZZZZZZZZZZZZZZ", false},
+		{"base32 lowercase", prefixid.UUIDPrefixer{Encoding: prefixid.UUIDBase32}, "3bmyw117dd278r1d00r17x8c68", true},
+		{"base32 crockford aliases", prefixid.UUIDPrefixer{Encoding: prefixid.UUIDBase32}, "3BMYWIL7DD278RIDOOR17X8C68", true},
+		{"base32 invalid character", prefixid.UUIDPrefixer{Encoding: prefixid.UUIDBase32}, "3BMYW117DD278R1D00R17X8CU8", false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			parsed, err := tc.prefixer.Parse(tc.input)
+			if tc.valid {
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				if parsed != id {
+					t.Errorf("Expected %s, got %s", id, parsed)
+				}
+			} else if err == nil {
+				t.Errorf("Expected error, got %s", parsed)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package prefixid
+
+import "sort"
+
+// prefixTrie indexes prefixes byte by byte so the candidates for a prefixed
+// ID can be found by walking it once. ASCII letters are folded to lowercase
+// so prefixers that fold prefix case are found too; the prefixer's Detach
+// has the final say on whether a candidate matches.
+type prefixTrie struct {
+	root trieNode
+}
+
+type trieNode struct {
+	children map[byte]*trieNode
+	// entries registered with a prefix ending at this node, sorted by entity type
+	entries []trieEntry
+}
+
+type trieEntry struct {
+	entityType string
+	prefix     string
+	// alias is the alias the prefix is or is scoped from, empty for the
+	// entity type's own prefix
+	alias string
+	// scope is the scope of a scoped prefix, empty for unscoped prefixes
+	scope string
+}
+
+// base returns the prefix or alias a scoped prefix is made of, and the
+// prefix itself if it's unscoped
+func (e *trieEntry) base() string {
+	if e.scope == "" {
+		return e.prefix
+	}
+	return e.prefix[:len(e.prefix)-len(ScopeSeparator)-len(e.scope)]
+}
+
+// insert adds an entry under its prefix
+func (t *prefixTrie) insert(entry trieEntry) {
+	prefix := entry.prefix
+	node := &t.root
+	for i := 0; i < len(prefix); i++ {
+		c := lowerASCII(prefix[i])
+		if node.children == nil {
+			node.children = make(map[byte]*trieNode)
+		}
+		child, ok := node.children[c]
+		if !ok {
+			child = &trieNode{}
+			node.children[c] = child
+		}
+		node = child
+	}
+
+	i := sort.Search(len(node.entries), func(i int) bool {
+		return node.entries[i].entityType >= entry.entityType
+	})
+	node.entries = append(node.entries, trieEntry{})
+	copy(node.entries[i+1:], node.entries[i:])
+	node.entries[i] = entry
+}
+
+// match calls fn for each entry whose prefix is a prefix of s, longest
+// prefix first and in entity type order for equal prefixes, until fn
+// returns true.
+func (t *prefixTrie) match(s string, fn func(entry *trieEntry) bool) bool {
+	var stack [32]*trieNode
+	nodes := append(stack[:0], &t.root)
+
+	node := &t.root
+	for i := 0; i < len(s); i++ {
+		child, ok := node.children[lowerASCII(s[i])]
+		if !ok {
+			break
+		}
+		node = child
+		nodes = append(nodes, node)
+	}
+
+	for depth := len(nodes) - 1; depth >= 0; depth-- {
+		entries := nodes[depth].entries
+		for i := range entries {
+			if fn(&entries[i]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func lowerASCII(c byte) byte {
+	if 'A' <= c && c <= 'Z' {
+		return c + ('a' - 'A')
+	}
+	return c
+}
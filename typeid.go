@@ -0,0 +1,148 @@
+package prefixid
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxTypeIDPrefixLength is the longest prefix allowed by the TypeID specification
+	MaxTypeIDPrefixLength = 63
+
+	typeIDSeparator = '_'
+	typeIDSuffixLen = 26
+)
+
+// TypeIDPrefixPolicy only accepts prefixes allowed by the TypeID
+// specification, see ValidateTypeIDPrefix. Prefixes may contain "_", since
+// TypeIDs are split at the last one, and may nest for the same reason.
+var TypeIDPrefixPolicy = PrefixPolicy{
+	MinLength: 1,
+	MaxLength: MaxTypeIDPrefixLength,
+	Charset:   "abcdefghijklmnopqrstuvwxyz_",
+	// "-" never appears in TypeID prefixes; the real separator is the last "_"
+	Separator:   "-",
+	AllowNested: true,
+	Check:       ValidateTypeIDPrefix,
+}
+
+// typeIDCodec is strict lowercase Crockford base32, without the aliases
+// accepted by UUIDBase32
+var typeIDCodec = newBaseCodec(crockfordBase32Lower)
+
+// TypeIDPrefixer implements IDPrefixer for UUIDs in the TypeID format: a
+// lowercase prefix, "_" and the UUID as 26 lowercase Crockford base32
+// characters, e.g. "user_01h455vb4pex5vsknk084sn02q". An empty prefix
+// produces the suffix alone. The specification recommends UUIDv7, see
+// UUIDv7Generator.
+//
+// Attach doesn't validate the prefix and Detach rejects prefixes outside the
+// TypeID grammar, so register them with TypeIDPrefixPolicy.
+type TypeIDPrefixer struct{}
+
+var _ IDPrefixer[uuid.UUID] = TypeIDPrefixer{}
+
+// Attach attaches a prefix to a UUID ID
+func (p TypeIDPrefixer) Attach(prefix string, id uuid.UUID) string {
+	suffix := typeIDCodec.encode(id[:])
+	if prefix == "" {
+		return suffix
+	}
+	return prefix + string(typeIDSeparator) + suffix
+}
+
+// Detach detaches a prefix from a TypeID. The prefix is everything before
+// the last "_", so prefixes may themselves contain underscores. Prefixes
+// that fail ValidateTypeIDPrefix never match.
+func (p TypeIDPrefixer) Detach(prefix string, prefixedID string) (string, bool) {
+	typePrefix, suffix, err := splitTypeID(prefixedID)
+	if err != nil || typePrefix != prefix || ValidateTypeIDPrefix(prefix) != nil {
+		return "", false
+	}
+	return suffix, true
+}
+
+// Parse parses a TypeID suffix into a UUID
+func (p TypeIDPrefixer) Parse(s string) (uuid.UUID, error) {
+	var id uuid.UUID
+	if err := decodeTypeIDSuffix(id[:], s); err != nil {
+		return uuid.Nil, err
+	}
+	return id, nil
+}
+
+// ParseTypeID parses a TypeID into its prefix and UUID
+func ParseTypeID(s string) (string, uuid.UUID, error) {
+	prefix, suffix, err := splitTypeID(s)
+	if err != nil {
+		return "", uuid.Nil, err
+	}
+
+	if err := ValidateTypeIDPrefix(prefix); err != nil {
+		return "", uuid.Nil, err
+	}
+
+	var id uuid.UUID
+	if err := decodeTypeIDSuffix(id[:], suffix); err != nil {
+		return "", uuid.Nil, err
+	}
+	return prefix, id, nil
+}
+
+// ValidateTypeID checks that a string is a valid TypeID
+func ValidateTypeID(s string) error {
+	_, _, err := ParseTypeID(s)
+	return err
+}
+
+// ValidateTypeIDPrefix checks a prefix against the TypeID grammar: at most
+// 63 characters of lowercase a-z and "_", not starting or ending with "_".
+// The empty prefix is valid.
+func ValidateTypeIDPrefix(prefix string) error {
+	if len(prefix) > MaxTypeIDPrefixLength {
+		return fmt.Errorf("%w: prefix %q is longer than %d characters", ErrInvalidTypeID, prefix, MaxTypeIDPrefixLength)
+	}
+
+	if prefix == "" {
+		return nil
+	}
+
+	if prefix[0] == typeIDSeparator || prefix[len(prefix)-1] == typeIDSeparator {
+		return fmt.Errorf("%w: prefix %q starts or ends with %q", ErrInvalidTypeID, prefix, typeIDSeparator)
+	}
+
+	for i := 0; i < len(prefix); i++ {
+		c := prefix[i]
+		if (c < 'a' || c > 'z') && c != typeIDSeparator {
+			return fmt.Errorf("%w: prefix %q contains %q", ErrInvalidTypeID, prefix, c)
+		}
+	}
+	return nil
+}
+
+// splitTypeID splits a TypeID at its last separator
+func splitTypeID(s string) (string, string, error) {
+	i := strings.LastIndexByte(s, typeIDSeparator)
+	if i < 0 {
+		return "", s, nil
+	}
+
+	if i == 0 {
+		return "", "", fmt.Errorf("%w: %q has a separator but no prefix", ErrInvalidTypeID, s)
+	}
+	return s[:i], s[i+1:], nil
+}
+
+// decodeTypeIDSuffix decodes a TypeID suffix into a 16-byte UUID
+func decodeTypeIDSuffix(dst []byte, suffix string) error {
+	if len(suffix) != typeIDSuffixLen {
+		return fmt.Errorf("%w: suffix %q is not %d characters", ErrInvalidTypeID, suffix, typeIDSuffixLen)
+	}
+
+	if err := typeIDCodec.decodeInto(dst, suffix); err != nil {
+		return fmt.Errorf("%w: suffix %q: %v", ErrInvalidTypeID, suffix, err)
+	}
+	return nil
+}
@@ -1,29 +1,48 @@
 package prefixid
 
 import (
-	"fmt"
-	"strings"
+	"slices"
 
 	"github.com/oklog/ulid/v2"
 )
 
 // ULIDPrefixer implements IDPrefixer for ULID IDs
-type ULIDPrefixer struct{}
+type ULIDPrefixer struct {
+	// Format controls how the prefix and ID are joined
+	Format Format
+}
+
+var (
+	_ IDPrefixer[ulid.ULID] = ULIDPrefixer{}
+	_ Appender[ulid.ULID]   = ULIDPrefixer{}
+)
 
-var _ IDPrefixer[ulid.ULID] = ULIDPrefixer{}
+// NewULIDPrefixer creates a ULIDPrefixer with the given format options
+func NewULIDPrefixer(opts ...FormatOption) ULIDPrefixer {
+	return ULIDPrefixer{Format: NewFormat(opts...)}
+}
 
 // Attach attaches a prefix to a ULID ID
 func (p ULIDPrefixer) Attach(prefix string, id ulid.ULID) string {
-	return fmt.Sprintf("%s_%s", prefix, id.String())
+	var buf [64]byte
+	return string(p.AppendPrefixed(buf[:0], prefix, id))
+}
+
+// AppendPrefixed appends a prefixed ULID ID to dst
+func (p ULIDPrefixer) AppendPrefixed(dst []byte, prefix string, id ulid.ULID) []byte {
+	dst = p.Format.appendHead(dst, prefix)
+
+	n := len(dst)
+	dst = slices.Grow(dst, ulid.EncodedSize)[:n+ulid.EncodedSize]
+	// MarshalTextTo only fails if the buffer isn't EncodedSize bytes
+	_ = id.MarshalTextTo(dst[n:])
+
+	return append(dst, p.Format.Suffix...)
 }
 
 // Detach detaches a prefix from a prefixed ID string
 func (p ULIDPrefixer) Detach(prefix string, prefixedID string) (string, bool) {
-	expectedPrefix := fmt.Sprintf("%s_", prefix)
-	if strings.HasPrefix(prefixedID, expectedPrefix) {
-		return strings.TrimPrefix(prefixedID, expectedPrefix), true
-	}
-	return "", false
+	return p.Format.Detach(prefix, prefixedID)
 }
 
 // Parse parses a string into a ULID
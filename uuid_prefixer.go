@@ -1,32 +1,115 @@
 package prefixid
 
 import (
+	"encoding/hex"
 	"fmt"
-	"strings"
 
 	"github.com/google/uuid"
 )
 
-// UUIDPrefixer implements IDPrefixer for UUID IDs
-type UUIDPrefixer struct{}
+// UUIDEncoding selects how UUIDPrefixer encodes the 16 bytes of a UUID
+type UUIDEncoding int
 
-var _ IDPrefixer[uuid.UUID] = UUIDPrefixer{}
+const (
+	// UUIDCanonical is the 36-character hyphenated form,
+	// e.g. 6ba7b810-9dad-11d1-80b4-00c04fd430c8
+	UUIDCanonical UUIDEncoding = iota
+	// UUIDBase58 is 22 characters of the Bitcoin base58 alphabet,
+	// e.g. EJ34kCVxxF9jHMKD4EgrAK
+	UUIDBase58
+	// UUIDBase62 is 22 characters of 0-9, A-Z and a-z,
+	// e.g. 3H8pGALtipnCnHud4zBiky
+	UUIDBase62
+	// UUIDBase32 is 26 characters of Crockford's base32, as used by ULIDs,
+	// e.g. 3BMYW117DD278R1D00R17X8C68
+	UUIDBase32
+)
+
+// codec returns the base codec for a compact encoding
+func (e UUIDEncoding) codec() *baseCodec {
+	switch e {
+	case UUIDBase58:
+		return base58Codec
+	case UUIDBase62:
+		return base62Codec
+	case UUIDBase32:
+		return crockfordCodec
+	default:
+		return nil
+	}
+}
+
+// UUIDPrefixer implements IDPrefixer for UUID IDs.
+//
+// The compact encodings are fixed-width with alphabets in ASCII order, so
+// encoded UUIDs sort like their bytes, which keeps time-ordered UUIDs such
+// as version 7 sortable.
+type UUIDPrefixer struct {
+	// Format controls how the prefix and ID are joined
+	Format Format
+	// Encoding of the UUID, UUIDCanonical if unset
+	Encoding UUIDEncoding
+	// AcceptCanonical makes Parse also accept the canonical hyphenated form
+	// when a compact Encoding is set
+	AcceptCanonical bool
+}
+
+var (
+	_ IDPrefixer[uuid.UUID] = UUIDPrefixer{}
+	_ Appender[uuid.UUID]   = UUIDPrefixer{}
+)
+
+// NewUUIDPrefixer creates a UUIDPrefixer with the given format options
+func NewUUIDPrefixer(opts ...FormatOption) UUIDPrefixer {
+	return UUIDPrefixer{Format: NewFormat(opts...)}
+}
 
 // Attach attaches a prefix to a UUID ID
 func (p UUIDPrefixer) Attach(prefix string, id uuid.UUID) string {
-	return fmt.Sprintf("%s_%s", prefix, id.String())
+	var buf [80]byte
+	return string(p.AppendPrefixed(buf[:0], prefix, id))
+}
+
+// AppendPrefixed appends a prefixed UUID ID to dst
+func (p UUIDPrefixer) AppendPrefixed(dst []byte, prefix string, id uuid.UUID) []byte {
+	dst = p.Format.appendHead(dst, prefix)
+	if codec := p.Encoding.codec(); codec != nil {
+		dst = codec.appendEncode(dst, id[:])
+	} else {
+		dst = appendCanonicalUUID(dst, id)
+	}
+	return append(dst, p.Format.Suffix...)
+}
+
+// appendCanonicalUUID appends the hyphenated form of a UUID to dst
+func appendCanonicalUUID(dst []byte, id uuid.UUID) []byte {
+	dst = hex.AppendEncode(dst, id[:4])
+	for _, group := range [][]byte{id[4:6], id[6:8], id[8:10], id[10:]} {
+		dst = append(dst, '-')
+		dst = hex.AppendEncode(dst, group)
+	}
+	return dst
 }
 
 // Detach detaches a prefix from a prefixed ID string
 func (p UUIDPrefixer) Detach(prefix string, prefixedID string) (string, bool) {
-	expectedPrefix := fmt.Sprintf("%s_", prefix)
-	if strings.HasPrefix(prefixedID, expectedPrefix) {
-		return strings.TrimPrefix(prefixedID, expectedPrefix), true
-	}
-	return "", false
+	return p.Format.Detach(prefix, prefixedID)
 }
 
 // Parse parses a string into a UUID
 func (p UUIDPrefixer) Parse(s string) (uuid.UUID, error) {
-	return uuid.Parse(s)
+	codec := p.Encoding.codec()
+	if codec == nil {
+		return uuid.Parse(s)
+	}
+
+	if p.AcceptCanonical && len(s) != codec.encodedLen(16) {
+		return uuid.Parse(s)
+	}
+
+	var id uuid.UUID
+	if err := codec.decodeInto(id[:], s); err != nil {
+		return uuid.Nil, fmt.Errorf("invalid UUID: %w", err)
+	}
+	return id, nil
 }